@@ -53,6 +53,10 @@ func (e *PrintExporter) ExportView(vd *view.Data) {
 			fmt.Printf("sum:          value=%v", v.Value)
 		case *view.LastValueData:
 			fmt.Printf("last:         value=%v", v.Value)
+		case *view.MinMaxData:
+			fmt.Printf("minmax:       min=%.1f max=%.1f", v.Min, v.Max)
+		case *view.SummaryData:
+			fmt.Printf("summary:      count=%v quantiles=%v", v.Count, v.Quantiles)
 		}
 		fmt.Println()
 
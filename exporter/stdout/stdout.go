@@ -0,0 +1,165 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stdout contains a trace exporter that writes spans to standard
+// output or any other io.Writer.
+//
+// It is intended for local debugging and should not be used for production
+// workloads.
+package stdout // import "go.opencensus.io/exporter/stdout"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// Format selects how the Exporter writes spans.
+type Format int
+
+const (
+	// FormatText writes each span as a single human-readable line.
+	FormatText Format = iota
+	// FormatJSON writes each span as a single line of JSON.
+	FormatJSON
+)
+
+// Options provides options for Exporter.
+type Options struct {
+	// Writer is where the spans are written. If nil, os.Stdout is used.
+	Writer io.Writer
+
+	// Format is the output format. The default is FormatText.
+	Format Format
+}
+
+var _ trace.Exporter = (*Exporter)(nil)
+
+// Exporter is a trace.Exporter that writes each exported span to an
+// io.Writer.
+type Exporter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	format Format
+}
+
+// NewExporter returns an Exporter configured with the given options.
+func NewExporter(o Options) *Exporter {
+	w := o.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	return &Exporter{w: w, format: o.Format}
+}
+
+// ExportSpan writes sd to the exporter's writer.
+func (e *Exporter) ExportSpan(sd *trace.SpanData) {
+	var b []byte
+	if e.format == FormatJSON {
+		b = formatJSON(sd)
+	} else {
+		b = formatText(sd)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.w.Write(b)
+}
+
+var spanKinds = map[int]string{
+	trace.SpanKindUnspecified: "unspecified",
+	trace.SpanKindServer:      "server",
+	trace.SpanKindClient:      "client",
+}
+
+func formatText(sd *trace.SpanData) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s trace_id=%s span_id=%s",
+		sd.StartTime.Format(time.RFC3339Nano), sd.Name, sd.TraceID, sd.SpanID)
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		fmt.Fprintf(&buf, " parent_span_id=%s", sd.ParentSpanID)
+	}
+	fmt.Fprintf(&buf, " sampled=%t kind=%s duration=%v status=%d",
+		sd.IsSampled(), spanKinds[sd.SpanKind], sd.EndTime.Sub(sd.StartTime), sd.Status.Code)
+	if sd.Status.Message != "" {
+		fmt.Fprintf(&buf, " status_message=%q", sd.Status.Message)
+	}
+	keys := make([]string, 0, len(sd.Attributes))
+	for k := range sd.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&buf, " %s=%v", k, sd.Attributes[k])
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+type jsonAnnotation struct {
+	Time       time.Time              `json:"time"`
+	Message    string                 `json:"message"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+type jsonSpan struct {
+	Name          string                 `json:"name"`
+	TraceID       string                 `json:"traceId"`
+	SpanID        string                 `json:"spanId"`
+	ParentSpanID  string                 `json:"parentSpanId,omitempty"`
+	Sampled       bool                   `json:"sampled"`
+	Kind          string                 `json:"kind"`
+	StartTime     time.Time              `json:"startTime"`
+	EndTime       time.Time              `json:"endTime"`
+	StatusCode    int32                  `json:"statusCode"`
+	StatusMessage string                 `json:"statusMessage,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
+	Annotations   []jsonAnnotation       `json:"annotations,omitempty"`
+}
+
+func formatJSON(sd *trace.SpanData) []byte {
+	s := jsonSpan{
+		Name:          sd.Name,
+		TraceID:       sd.TraceID.String(),
+		SpanID:        sd.SpanID.String(),
+		Sampled:       sd.IsSampled(),
+		Kind:          spanKinds[sd.SpanKind],
+		StartTime:     sd.StartTime,
+		EndTime:       sd.EndTime,
+		StatusCode:    sd.Status.Code,
+		StatusMessage: sd.Status.Message,
+		Attributes:    sd.Attributes,
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentSpanID = sd.ParentSpanID.String()
+	}
+	for _, a := range sd.Annotations {
+		s.Annotations = append(s.Annotations, jsonAnnotation{
+			Time:       a.Time,
+			Message:    a.Message,
+			Attributes: a.Attributes,
+		})
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return []byte(fmt.Sprintf("{\"error\":%q}\n", err.Error()))
+	}
+	return append(b, '\n')
+}
@@ -0,0 +1,80 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+func testSpanData() *trace.SpanData {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID:      trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:       trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceOptions: 1,
+		},
+		ParentSpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		SpanKind:     trace.SpanKindServer,
+		Name:         "/foo",
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   map[string]interface{}{"b": int64(1), "a": "x"},
+		Status:       trace.Status{Code: trace.StatusCodeNotFound, Message: "missing"},
+	}
+}
+
+func TestExportSpanText(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewExporter(Options{Writer: &buf})
+	e.ExportSpan(testSpanData())
+
+	want := `2026-01-02T03:04:05Z /foo trace_id=0102030405060708090a0b0c0d0e0f10 span_id=0102030405060708 parent_span_id=0807060504030201 sampled=true kind=server duration=1s status=5 status_message="missing" a=x b=1` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("ExportSpan wrote\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExportSpanJSON(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewExporter(Options{Writer: &buf, Format: FormatJSON})
+	e.ExportSpan(testSpanData())
+
+	if !strings.HasSuffix(buf.String(), "\n") {
+		t.Errorf("ExportSpan output %q is not newline terminated", buf.String())
+	}
+	var got jsonSpan
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("cannot unmarshal %q: %v", buf.String(), err)
+	}
+	if got.Name != "/foo" {
+		t.Errorf("Name = %q; want %q", got.Name, "/foo")
+	}
+	if want := "0102030405060708090a0b0c0d0e0f10"; got.TraceID != want {
+		t.Errorf("TraceID = %q; want %q", got.TraceID, want)
+	}
+	if want := "0807060504030201"; got.ParentSpanID != want {
+		t.Errorf("ParentSpanID = %q; want %q", got.ParentSpanID, want)
+	}
+	if got.Kind != "server" || !got.Sampled || got.StatusCode != trace.StatusCodeNotFound {
+		t.Errorf("got kind=%q sampled=%v status=%d; want server, true, %d", got.Kind, got.Sampled, got.StatusCode, trace.StatusCodeNotFound)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// NewIDGenerator returns a trace.IDGenerator whose trace IDs hold the
+// current time in seconds since the epoch in their first 4 bytes, as X-Ray
+// expects, followed by 12 random bytes. Install it with
+//
+//	trace.ApplyConfig(trace.Config{IDGenerator: xray.NewIDGenerator()})
+//
+// so that trace IDs are sent to X-Ray unchanged; see Exporter.
+func NewIDGenerator() trace.IDGenerator {
+	var seed int64
+	binary.Read(crand.Reader, binary.LittleEndian, &seed)
+	return &idGenerator{rand: rand.New(rand.NewSource(seed))}
+}
+
+type idGenerator struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (g *idGenerator) NewTraceID() [16]byte {
+	var tid [16]byte
+	binary.BigEndian.PutUint32(tid[0:4], uint32(time.Now().Unix()))
+	g.mu.Lock()
+	g.rand.Read(tid[4:])
+	g.mu.Unlock()
+	return tid
+}
+
+func (g *idGenerator) NewSpanID() [8]byte {
+	var sid [8]byte
+	g.mu.Lock()
+	for sid == ([8]byte{}) {
+		g.rand.Read(sid[:])
+	}
+	g.mu.Unlock()
+	return sid
+}
@@ -0,0 +1,275 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.opencensus.io/trace"
+)
+
+// Span attributes mapped to the http block, as recorded by the
+// go.opencensus.io/plugin/ochttp package.
+const (
+	methodAttribute     = "http.method"
+	urlAttribute        = "http.url"
+	userAgentAttribute  = "http.user_agent"
+	statusCodeAttribute = "http.status_code"
+	hostAttribute       = "http.host"
+)
+
+// maxNameLength is the max length of segment names accepted by X-Ray.
+const maxNameLength = 200
+
+type segment struct {
+	Name      string  `json:"name"`
+	ID        string  `json:"id"`
+	TraceID   string  `json:"trace_id"`
+	ParentID  string  `json:"parent_id,omitempty"`
+	Type      string  `json:"type,omitempty"`
+	Namespace string  `json:"namespace,omitempty"`
+	StartTime float64 `json:"start_time"`
+	EndTime   float64 `json:"end_time"`
+
+	HTTP     *httpInfo `json:"http,omitempty"`
+	Error    bool      `json:"error,omitempty"`
+	Fault    bool      `json:"fault,omitempty"`
+	Throttle bool      `json:"throttle,omitempty"`
+	Cause    *cause    `json:"cause,omitempty"`
+
+	Annotations map[string]interface{}            `json:"annotations,omitempty"`
+	Metadata    map[string]map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type httpInfo struct {
+	Request  *httpRequest  `json:"request,omitempty"`
+	Response *httpResponse `json:"response,omitempty"`
+}
+
+type httpRequest struct {
+	Method    string `json:"method,omitempty"`
+	URL       string `json:"url,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+type httpResponse struct {
+	Status int64 `json:"status,omitempty"`
+}
+
+type cause struct {
+	Exceptions []exception `json:"exceptions"`
+}
+
+type exception struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// X-Ray rejects trace IDs whose time is not within the last 30 days, or is
+// in the future.
+const (
+	maxTraceAge  = 28 * 24 * time.Hour
+	maxClockSkew = 5 * time.Minute
+)
+
+// traceIDString returns the trace ID of sd in the X-Ray format: the version
+// 1, the first 4 bytes of the ID, which X-Ray expects to hold the start time
+// of the trace in seconds, and the other 12 bytes, all in hex and separated
+// by dashes.
+//
+// Trace IDs not generated by NewIDGenerator almost never hold an accepted
+// time, so when the time is not plausible for a span starting at
+// sd.StartTime, it is replaced by the start time of sd. X-Ray then splits the
+// spans of such a trace that started in different seconds into several
+// traces.
+func traceIDString(sd *trace.SpanData) string {
+	id := sd.TraceID
+	epoch := time.Unix(int64(binary.BigEndian.Uint32(id[0:4])), 0)
+	if epoch.Before(sd.StartTime.Add(-maxTraceAge)) || epoch.After(sd.StartTime.Add(maxClockSkew)) {
+		binary.BigEndian.PutUint32(id[0:4], uint32(sd.StartTime.Unix()))
+	}
+	return "1-" + hex.EncodeToString(id[0:4]) + "-" + hex.EncodeToString(id[4:16])
+}
+
+// seconds returns t as seconds since the epoch.
+func seconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// isSegment reports whether sd is sent as a segment rather than a
+// subsegment.
+func isSegment(sd *trace.SpanData) bool {
+	return sd.SpanKind == trace.SpanKindServer || sd.HasRemoteParent || sd.ParentSpanID == (trace.SpanID{})
+}
+
+func makeSegment(sd *trace.SpanData, serviceName string) *segment {
+	s := &segment{
+		Name:      sd.Name,
+		ID:        sd.SpanID.String(),
+		TraceID:   traceIDString(sd),
+		StartTime: seconds(sd.StartTime),
+		EndTime:   seconds(sd.EndTime),
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentID = sd.ParentSpanID.String()
+	}
+	if isSegment(sd) {
+		if serviceName != "" {
+			s.Name = serviceName
+		}
+	} else {
+		s.Type = "subsegment"
+	}
+	if sd.SpanKind == trace.SpanKindClient {
+		s.Namespace = "remote"
+		// Remote subsegments are named after the called service.
+		if host, ok := sd.Attributes[hostAttribute].(string); ok && host != "" {
+			s.Name = host
+		}
+	}
+	s.Name = sanitizeName(s.Name)
+
+	attrs := make(map[string]interface{}, len(sd.Attributes))
+	for k, v := range sd.Attributes {
+		attrs[k] = v
+	}
+	s.HTTP = takeHTTPInfo(attrs)
+	if s.HTTP != nil && s.HTTP.Response != nil {
+		setHTTPFlags(s, s.HTTP.Response.Status)
+	} else {
+		setStatusFlags(s, sd.Status.Code)
+	}
+	if (s.Error || s.Fault) && sd.Status.Message != "" {
+		s.Cause = &cause{Exceptions: []exception{{ID: s.ID, Message: sd.Status.Message}}}
+	}
+
+	for k, v := range attrs {
+		switch v.(type) {
+		case string, bool, int64, float64:
+			if s.Annotations == nil {
+				s.Annotations = make(map[string]interface{})
+			}
+			s.Annotations[sanitizeAnnotationKey(k)] = v
+		default:
+			if s.Metadata == nil {
+				s.Metadata = map[string]map[string]interface{}{"default": {}}
+			}
+			s.Metadata["default"][k] = v
+		}
+	}
+	return s
+}
+
+// takeHTTPInfo returns the http block described by the http attributes in
+// attrs, if any, and removes them from attrs.
+func takeHTTPInfo(attrs map[string]interface{}) *httpInfo {
+	var (
+		req  httpRequest
+		resp httpResponse
+	)
+	take := func(key string) string {
+		v, _ := attrs[key].(string)
+		delete(attrs, key)
+		return v
+	}
+	req.Method = take(methodAttribute)
+	req.URL = take(urlAttribute)
+	req.UserAgent = take(userAgentAttribute)
+	if status, ok := attrs[statusCodeAttribute].(int64); ok {
+		resp.Status = status
+		delete(attrs, statusCodeAttribute)
+	}
+
+	var info httpInfo
+	if req != (httpRequest{}) {
+		info.Request = &req
+	}
+	if resp != (httpResponse{}) {
+		info.Response = &resp
+	}
+	if info.Request == nil && info.Response == nil {
+		return nil
+	}
+	return &info
+}
+
+// setHTTPFlags sets the error flags of s for an HTTP response status: error
+// for 4xx, with throttle for 429, and fault for 5xx.
+func setHTTPFlags(s *segment, status int64) {
+	switch {
+	case status == 429:
+		s.Error, s.Throttle = true, true
+	case status >= 400 && status < 500:
+		s.Error = true
+	case status >= 500:
+		s.Fault = true
+	}
+}
+
+// setStatusFlags sets the error flags of s for a trace status code: error
+// for the codes caused by the caller, with throttle for RESOURCE_EXHAUSTED,
+// and fault for the others.
+func setStatusFlags(s *segment, code int32) {
+	switch code {
+	case trace.StatusCodeOK:
+	case trace.StatusCodeResourceExhausted:
+		s.Error, s.Throttle = true, true
+	case trace.StatusCodeCancelled,
+		trace.StatusCodeInvalidArgument,
+		trace.StatusCodeNotFound,
+		trace.StatusCodeAlreadyExists,
+		trace.StatusCodePermissionDenied,
+		trace.StatusCodeFailedPrecondition,
+		trace.StatusCodeOutOfRange,
+		trace.StatusCodeUnauthenticated:
+		s.Error = true
+	default:
+		s.Fault = true
+	}
+}
+
+// sanitizeName replaces the characters X-Ray does not accept in segment
+// names with underscores and truncates the name to 200 characters.
+func sanitizeName(name string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range name {
+		if n == maxNameLength {
+			break
+		}
+		if !unicode.IsLetter(r) && !unicode.IsNumber(r) && !unicode.IsSpace(r) && !strings.ContainsRune(`_.:/%&#=+\-@`, r) {
+			r = '_'
+		}
+		b.WriteRune(r)
+		n++
+	}
+	return b.String()
+}
+
+// sanitizeAnnotationKey replaces the characters other than ASCII letters,
+// digits and underscores, which X-Ray does not accept in annotation keys,
+// with underscores.
+func sanitizeAnnotationKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, key)
+}
@@ -0,0 +1,174 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package xray contains a trace exporter that sends spans to the AWS X-Ray
+// daemon over UDP as segment documents
+// (https://docs.aws.amazon.com/xray/latest/devguide/xray-api-segmentdocuments.html).
+//
+// Server spans and spans without a local parent are sent as segments, and
+// the other spans as subsegments of their parent. Client spans are remote
+// subsegments, which X-Ray shows as calls to downstream services.
+//
+// X-Ray expects trace IDs to start with the time of the trace. Install the
+// IDGenerator returned by NewIDGenerator so that new traces get such IDs.
+package xray // import "go.opencensus.io/exporter/xray"
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+
+	"go.opencensus.io/trace"
+)
+
+const (
+	defaultDaemonAddress = "127.0.0.1:2000"
+	defaultMaxBatchSize  = 100
+
+	// header precedes every segment document sent to the daemon.
+	header = `{"format": "json", "version": 1}` + "\n"
+)
+
+type options struct {
+	daemonAddress string
+	serviceName   string
+	maxBatchSize  int
+}
+
+// Option configures an Exporter.
+type Option func(*options)
+
+// WithDaemonAddress sets the UDP address of the X-Ray daemon. It defaults to
+// the AWS_XRAY_DAEMON_ADDRESS environment variable if set, and to
+// 127.0.0.1:2000 otherwise.
+func WithDaemonAddress(addr string) Option {
+	return func(o *options) {
+		o.daemonAddress = addr
+	}
+}
+
+// WithServiceName sets the name of the segments, which X-Ray uses as the
+// name of the service in the service map. By default segments are named
+// after their span.
+func WithServiceName(name string) Option {
+	return func(o *options) {
+		o.serviceName = name
+	}
+}
+
+// WithMaxBatchSize sets the number of spans that ExportSpansWithError turns
+// into segment documents and sends at a time, which bounds the memory used
+// for the batches of the trace package. It defaults to 100. Each span is
+// still sent in its own datagram, as the daemon expects one segment document
+// per datagram.
+func WithMaxBatchSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxBatchSize = n
+		}
+	}
+}
+
+var _ trace.BatchErrorExporter = (*Exporter)(nil)
+
+// Exporter is a trace.Exporter that sends spans to the X-Ray daemon.
+//
+// Exporter implements trace.BatchErrorExporter: when it is registered with
+// trace.RegisterExporter, spans are batched according to the trace.Config
+// and sent from the goroutine of the batcher, and errors are passed to the
+// handler set with trace.SetExportErrorHandler.
+type Exporter struct {
+	o    options
+	conn net.Conn
+}
+
+// NewExporter returns an Exporter configured with the given options. It
+// returns an error if the daemon address cannot be resolved.
+func NewExporter(opts ...Option) (*Exporter, error) {
+	o := options{
+		daemonAddress: os.Getenv("AWS_XRAY_DAEMON_ADDRESS"),
+		maxBatchSize:  defaultMaxBatchSize,
+	}
+	if o.daemonAddress == "" {
+		o.daemonAddress = defaultDaemonAddress
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	conn, err := net.Dial("udp", o.daemonAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{o: o, conn: conn}, nil
+}
+
+// ExportSpan sends sd on its own. It is only called when the Exporter is
+// used outside of trace.RegisterExporter, which batches spans instead.
+func (e *Exporter) ExportSpan(sd *trace.SpanData) {
+	e.ExportSpans([]*trace.SpanData{sd})
+}
+
+// ExportSpans sends sds, reporting errors with trace.ReportExportError.
+func (e *Exporter) ExportSpans(sds []*trace.SpanData) {
+	if err := e.ExportSpansWithError(sds); err != nil {
+		trace.ReportExportError(err)
+	}
+}
+
+// ExportSpansWithError sends each of sds in its own datagram, in batches of
+// at most the size set with WithMaxBatchSize, and returns the first error.
+func (e *Exporter) ExportSpansWithError(sds []*trace.SpanData) error {
+	var err error
+	for len(sds) > 0 {
+		n := len(sds)
+		if n > e.o.maxBatchSize {
+			n = e.o.maxBatchSize
+		}
+		if serr := e.send(e.segments(sds[:n])); serr != nil && err == nil {
+			err = serr
+		}
+		sds = sds[n:]
+	}
+	return err
+}
+
+// segments returns the segment documents of sds.
+func (e *Exporter) segments(sds []*trace.SpanData) []*segment {
+	segments := make([]*segment, len(sds))
+	for i, sd := range sds {
+		segments[i] = makeSegment(sd, e.o.serviceName)
+	}
+	return segments
+}
+
+// Close closes the connection to the daemon.
+func (e *Exporter) Close() error {
+	return e.conn.Close()
+}
+
+// send sends each of segments in its own datagram, and returns the first
+// error.
+func (e *Exporter) send(segments []*segment) error {
+	var err error
+	for _, s := range segments {
+		b, merr := json.Marshal(s)
+		if merr == nil {
+			_, merr = e.conn.Write(append([]byte(header), b...))
+		}
+		if merr != nil && err == nil {
+			err = merr
+		}
+	}
+	return err
+}
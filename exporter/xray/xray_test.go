@@ -0,0 +1,314 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xray
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+func clientSpanData() *trace.SpanData {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID:      trace.TraceID{0x69, 0x57, 0x35, 0x00, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+			SpanID:       trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceOptions: 1,
+		},
+		ParentSpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		SpanKind:     trace.SpanKindClient,
+		Name:         "/users",
+		StartTime:    start,
+		EndTime:      start.Add(1500 * time.Millisecond),
+		Attributes: map[string]interface{}{
+			"http.method":      "GET",
+			"http.url":         "http://users.example.com/users?id=1",
+			"http.host":        "users.example.com",
+			"http.user_agent":  "test",
+			"http.status_code": int64(503),
+			"user.id":          int64(1),
+			"roles":            []string{"a", "b"},
+		},
+		Status: trace.Status{Code: trace.StatusCodeUnavailable, Message: "Service Unavailable"},
+	}
+}
+
+func TestExportClientSpan(t *testing.T) {
+	daemon, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer daemon.Close()
+
+	e, err := NewExporter(WithDaemonAddress(daemon.LocalAddr().String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	e.ExportSpan(clientSpanData())
+
+	buf := make([]byte, 64*1024)
+	daemon.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := daemon.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitN(string(buf[:n]), "\n", 2)
+	if len(lines) != 2 || lines[0] != `{"format": "json", "version": 1}` {
+		t.Fatalf("datagram = %q; want a header line and a segment document", buf[:n])
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"name":       "users.example.com",
+		"id":         "0102030405060708",
+		"trace_id":   "1-69573500-0102030405060708090a0b0c",
+		"parent_id":  "0807060504030201",
+		"type":       "subsegment",
+		"namespace":  "remote",
+		"start_time": 1767323045.0,
+		"end_time":   1767323046.5,
+		"http": map[string]interface{}{
+			"request": map[string]interface{}{
+				"method":     "GET",
+				"url":        "http://users.example.com/users?id=1",
+				"user_agent": "test",
+			},
+			"response": map[string]interface{}{"status": 503.0},
+		},
+		"fault": true,
+		"cause": map[string]interface{}{
+			"exceptions": []interface{}{
+				map[string]interface{}{"id": "0102030405060708", "message": "Service Unavailable"},
+			},
+		},
+		"annotations": map[string]interface{}{
+			"http_host": "users.example.com",
+			"user_id":   1.0,
+		},
+		"metadata": map[string]interface{}{
+			"default": map[string]interface{}{"roles": []interface{}{"a", "b"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("segment document:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestRegisteredExporter(t *testing.T) {
+	daemon, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer daemon.Close()
+
+	e, err := NewExporter(WithDaemonAddress(daemon.LocalAddr().String()), WithServiceName("svc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	trace.RegisterExporter(e)
+	_, span := trace.StartSpan(context.Background(), "span", trace.WithSampler(trace.AlwaysSample()))
+	span.End()
+	// Unregistering sends the spans still batched.
+	trace.UnregisterExporter(e)
+
+	buf := make([]byte, 64*1024)
+	daemon.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := daemon.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(buf[:n]), `"name":"svc"`) {
+		t.Errorf("datagram = %q; want a segment named svc", buf[:n])
+	}
+}
+
+func TestMaxBatchSize(t *testing.T) {
+	daemon, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer daemon.Close()
+
+	for _, tt := range []struct {
+		opts []Option
+		want int
+	}{
+		{nil, defaultMaxBatchSize},
+		{[]Option{WithMaxBatchSize(0)}, defaultMaxBatchSize},
+		{[]Option{WithMaxBatchSize(2)}, 2},
+	} {
+		e, err := NewExporter(append(tt.opts, WithDaemonAddress(daemon.LocalAddr().String()))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := e.o.maxBatchSize; got != tt.want {
+			t.Errorf("maxBatchSize = %d; want %d", got, tt.want)
+		}
+		e.Close()
+	}
+
+	e, err := NewExporter(WithDaemonAddress(daemon.LocalAddr().String()), WithMaxBatchSize(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	var sds []*trace.SpanData
+	for i := 0; i < 5; i++ {
+		sd := clientSpanData()
+		sd.SpanID = trace.SpanID{7, byte(i)}
+		sds = append(sds, sd)
+	}
+	if err := e.ExportSpansWithError(sds); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 64*1024)
+	for i := range sds {
+		daemon.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := daemon.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("datagram %d: %v", i, err)
+		}
+		if want := fmt.Sprintf(`"id":"070%d000000000000"`, i); !strings.Contains(string(buf[:n]), want) {
+			t.Errorf("datagram %d = %q; want a segment with %s", i, buf[:n], want)
+		}
+	}
+}
+
+func TestSegmentFlags(t *testing.T) {
+	tests := []struct {
+		name                   string
+		status                 int64
+		code                   int32
+		error, fault, throttle bool
+	}{
+		{name: "ok", code: trace.StatusCodeOK},
+		{name: "http 404", status: 404, code: trace.StatusCodeNotFound, error: true},
+		{name: "http 429", status: 429, code: trace.StatusCodeUnknown, error: true, throttle: true},
+		{name: "http 500", status: 500, code: trace.StatusCodeUnknown, fault: true},
+		{name: "invalid argument", code: trace.StatusCodeInvalidArgument, error: true},
+		{name: "resource exhausted", code: trace.StatusCodeResourceExhausted, error: true, throttle: true},
+		{name: "internal", code: trace.StatusCodeInternal, fault: true},
+	}
+	for _, tt := range tests {
+		sd := &trace.SpanData{Name: "op", Status: trace.Status{Code: tt.code}}
+		if tt.status != 0 {
+			sd.Attributes = map[string]interface{}{"http.status_code": tt.status}
+		}
+		s := makeSegment(sd, "svc")
+		if s.Error != tt.error || s.Fault != tt.fault || s.Throttle != tt.throttle {
+			t.Errorf("%s: error, fault, throttle = %t, %t, %t; want %t, %t, %t",
+				tt.name, s.Error, s.Fault, s.Throttle, tt.error, tt.fault, tt.throttle)
+		}
+		if s.Name != "svc" || s.Type != "" {
+			t.Errorf("%s: root span sent as %q %q; want segment named after the service", tt.name, s.Type, s.Name)
+		}
+	}
+}
+
+func TestTraceIDFromDefaultGenerator(t *testing.T) {
+	// Spans started with the default IDGenerator hold random bytes where X-Ray
+	// expects the time of the trace.
+	_, span := trace.StartSpan(context.Background(), "span", trace.WithSampler(trace.AlwaysSample()))
+	var sd *trace.SpanData
+	span.OnEnd(func(d *trace.SpanData) { sd = d })
+	span.End()
+
+	got := traceIDString(sd)
+	parts := strings.Split(got, "-")
+	if len(parts) != 3 || parts[0] != "1" {
+		t.Fatalf("traceIDString() = %q; want 1-<time>-<id>", got)
+	}
+	b, err := hex.DecodeString(parts[1])
+	if err != nil || len(b) != 4 {
+		t.Fatalf("traceIDString() = %q; bad time", got)
+	}
+	epoch := time.Unix(int64(binary.BigEndian.Uint32(b)), 0)
+	if epoch.Before(sd.StartTime.Add(-maxTraceAge)) || epoch.After(sd.StartTime.Add(maxClockSkew)) {
+		t.Errorf("traceIDString() = %q; time %v is not accepted for a span started at %v", got, epoch, sd.StartTime)
+	}
+	if want := hex.EncodeToString(sd.TraceID[4:]); parts[2] != want {
+		t.Errorf("traceIDString() = %q; want ID %q", got, want)
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	now := time.Now()
+	xrayID := NewIDGenerator().NewTraceID()
+	tests := []struct {
+		name    string
+		traceID trace.TraceID
+		want    string
+	}{
+		{
+			name:    "xray generator",
+			traceID: xrayID,
+			want:    "1-" + hex.EncodeToString(xrayID[:4]) + "-" + hex.EncodeToString(xrayID[4:]),
+		},
+		{
+			name:    "64-bit trace ID",
+			traceID: trace.TraceID{8: 1, 15: 2},
+			want:    "1-" + hex.EncodeToString(timeBytes(now)) + "-000000000100000000000002",
+		},
+		{
+			name:    "time too old",
+			traceID: trace.TraceID{0, 0, 0, 1, 15: 1},
+			want:    "1-" + hex.EncodeToString(timeBytes(now)) + "-000000000000000000000001",
+		},
+	}
+	for _, tt := range tests {
+		sd := &trace.SpanData{SpanContext: trace.SpanContext{TraceID: tt.traceID}, StartTime: now}
+		if got := traceIDString(sd); got != tt.want {
+			t.Errorf("%s: traceIDString() = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIDGenerator(t *testing.T) {
+	g := NewIDGenerator()
+	before := time.Now().Unix()
+	tid := g.NewTraceID()
+	after := time.Now().Unix()
+	if epoch := int64(binary.BigEndian.Uint32(tid[:4])); epoch < before || epoch > after {
+		t.Errorf("trace ID time = %d; want between %d and %d", epoch, before, after)
+	}
+	if tid == g.NewTraceID() {
+		t.Errorf("NewTraceID returned the same ID twice: %v", tid)
+	}
+	if sid := g.NewSpanID(); sid == ([8]byte{}) {
+		t.Error("NewSpanID returned a zero span ID")
+	}
+}
+
+func timeBytes(t time.Time) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, uint32(t.Unix()))
+	return b
+}
@@ -0,0 +1,269 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zipkin contains a trace exporter that sends spans to a Zipkin
+// collector using the Zipkin v2 JSON span model
+// (https://zipkin.io/zipkin-api/#/default/post_spans).
+package zipkin // import "go.opencensus.io/exporter/zipkin"
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// Endpoint describes the network context of the process producing spans.
+type Endpoint struct {
+	ServiceName string `json:"serviceName,omitempty"`
+	IPv4        string `json:"ipv4,omitempty"`
+	IPv6        string `json:"ipv6,omitempty"`
+	Port        int    `json:"port,omitempty"`
+}
+
+// Options provides options for Exporter.
+type Options struct {
+	// CollectorURL is the URL spans are posted to, for example
+	// http://localhost:9411/api/v2/spans.
+	CollectorURL string
+
+	// LocalEndpoint is reported as the local endpoint of every span.
+	LocalEndpoint *Endpoint
+
+	// Client is the HTTP client used to post spans. If nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+}
+
+var _ trace.BatchErrorExporter = (*Exporter)(nil)
+
+// Exporter is a trace.Exporter that posts spans to a Zipkin collector.
+//
+// Exporter implements trace.BatchErrorExporter: when it is registered with
+// trace.RegisterExporter, spans are batched according to the trace.Config
+// and posted from the goroutine of the batcher, errors are passed to the
+// handler set with trace.SetExportErrorHandler, and trace.Shutdown posts the
+// spans still pending.
+type Exporter struct {
+	o Options
+}
+
+// NewExporter returns an Exporter configured with the given options.
+func NewExporter(o Options) *Exporter {
+	if o.Client == nil {
+		o.Client = http.DefaultClient
+	}
+	return &Exporter{o: o}
+}
+
+// ExportSpan posts sd on its own. It is only called when the Exporter is
+// used outside of trace.RegisterExporter, which calls ExportSpans instead.
+func (e *Exporter) ExportSpan(sd *trace.SpanData) {
+	e.ExportSpans([]*trace.SpanData{sd})
+}
+
+// ExportSpans posts sds, reporting errors with trace.ReportExportError.
+func (e *Exporter) ExportSpans(sds []*trace.SpanData) {
+	if err := e.ExportSpansWithError(sds); err != nil {
+		trace.ReportExportError(err)
+	}
+}
+
+// ExportSpansWithError posts sds in a single request.
+func (e *Exporter) ExportSpansWithError(sds []*trace.SpanData) error {
+	spans := make([]*span, len(sds))
+	for i, sd := range sds {
+		spans[i] = zipkinSpan(sd, e.o.LocalEndpoint)
+	}
+	return e.post(context.Background(), spans)
+}
+
+func (e *Exporter) post(ctx context.Context, spans []*span) error {
+	b, err := json.Marshal(spans)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", e.o.CollectorURL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.o.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("zipkin collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Tags carrying the span status.
+const (
+	statusCodeTagKey        = "opencensus.status_code"
+	statusDescriptionTagKey = "opencensus.status_description"
+	errorTagKey             = "error"
+)
+
+var canonicalCodes = [...]string{
+	"OK",
+	"CANCELLED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+func canonicalCodeString(code int32) string {
+	if code < 0 || int(code) >= len(canonicalCodes) {
+		return "error code " + strconv.FormatInt(int64(code), 10)
+	}
+	return canonicalCodes[code]
+}
+
+type annotation struct {
+	Timestamp int64  `json:"timestamp"`
+	Value     string `json:"value"`
+}
+
+type span struct {
+	TraceID       string            `json:"traceId"`
+	ID            string            `json:"id"`
+	ParentID      string            `json:"parentId,omitempty"`
+	Name          string            `json:"name,omitempty"`
+	Kind          string            `json:"kind,omitempty"`
+	Timestamp     int64             `json:"timestamp,omitempty"`
+	Duration      int64             `json:"duration,omitempty"`
+	LocalEndpoint *Endpoint         `json:"localEndpoint,omitempty"`
+	Annotations   []annotation      `json:"annotations,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+}
+
+// microseconds returns t as microseconds since the epoch.
+func microseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Microsecond)
+}
+
+// traceIDString returns the trace ID as 16 hex characters if its high 8 bytes
+// are zero, and as 32 hex characters otherwise.
+func traceIDString(id trace.TraceID) string {
+	for _, b := range id[:8] {
+		if b != 0 {
+			return hex.EncodeToString(id[:])
+		}
+	}
+	return hex.EncodeToString(id[8:])
+}
+
+func zipkinSpan(sd *trace.SpanData, local *Endpoint) *span {
+	s := &span{
+		TraceID:       traceIDString(sd.TraceID),
+		ID:            sd.SpanID.String(),
+		Name:          sd.Name,
+		Timestamp:     microseconds(sd.StartTime),
+		LocalEndpoint: local,
+	}
+	if sd.ParentSpanID != (trace.SpanID{}) {
+		s.ParentID = sd.ParentSpanID.String()
+	}
+	if d := sd.EndTime.Sub(sd.StartTime); d > 0 {
+		s.Duration = int64(d / time.Microsecond)
+		if s.Duration == 0 {
+			// Zipkin treats a zero duration as unset.
+			s.Duration = 1
+		}
+	}
+	switch sd.SpanKind {
+	case trace.SpanKindClient:
+		s.Kind = "CLIENT"
+	case trace.SpanKindServer:
+		s.Kind = "SERVER"
+	}
+
+	for _, a := range sd.Annotations {
+		s.Annotations = append(s.Annotations, annotation{
+			Timestamp: microseconds(a.Time),
+			Value:     a.Message,
+		})
+	}
+	for _, m := range sd.MessageEvents {
+		var value string
+		switch m.EventType {
+		case trace.MessageEventTypeSent:
+			value = "SENT"
+		case trace.MessageEventTypeRecv:
+			value = "RECV"
+		default:
+			continue
+		}
+		s.Annotations = append(s.Annotations, annotation{
+			Timestamp: microseconds(m.Time),
+			Value:     value,
+		})
+	}
+
+	tags := make(map[string]string, len(sd.Attributes)+3)
+	for k, v := range sd.Attributes {
+		switch v := v.(type) {
+		case string:
+			tags[k] = v
+		case bool:
+			tags[k] = strconv.FormatBool(v)
+		case int64:
+			tags[k] = strconv.FormatInt(v, 10)
+		case float64:
+			tags[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		case []string, []int64:
+			b, _ := json.Marshal(v)
+			tags[k] = string(b)
+		default:
+			tags[k] = fmt.Sprint(v)
+		}
+	}
+	if sd.Status.Code != trace.StatusCodeOK || sd.Status.Message != "" {
+		tags[statusCodeTagKey] = canonicalCodeString(sd.Status.Code)
+		if sd.Status.Message != "" {
+			tags[statusDescriptionTagKey] = sd.Status.Message
+		}
+	}
+	if sd.Status.Code != trace.StatusCodeOK {
+		tags[errorTagKey] = canonicalCodeString(sd.Status.Code)
+	}
+	if len(tags) > 0 {
+		s.Tags = tags
+	}
+	return s
+}
@@ -0,0 +1,253 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zipkin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+type collector struct {
+	mu      sync.Mutex
+	batches [][]map[string]interface{}
+	errs    []string
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r.Method != "POST" {
+		c.errs = append(c.errs, "method = "+r.Method)
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+		c.errs = append(c.errs, "Content-Type = "+ct)
+	}
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		c.errs = append(c.errs, err.Error())
+		return
+	}
+	var batch []map[string]interface{}
+	if err := json.Unmarshal(b, &batch); err != nil {
+		c.errs = append(c.errs, err.Error())
+		return
+	}
+	c.batches = append(c.batches, batch)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// batchSizes returns the number of spans in each posted batch.
+func (c *collector) batchSizes() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var sizes []int
+	for _, b := range c.batches {
+		sizes = append(sizes, len(b))
+	}
+	return sizes
+}
+
+func testSpanData() *trace.SpanData {
+	start := time.Unix(1500000000, 0)
+	return &trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID:      trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:       trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+			TraceOptions: 1,
+		},
+		ParentSpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		SpanKind:     trace.SpanKindServer,
+		Name:         "/foo",
+		StartTime:    start,
+		EndTime:      start.Add(24 * time.Millisecond),
+		Attributes: map[string]interface{}{
+			"stringkey": "value",
+			"intkey":    int64(42),
+			"boolkey":   true,
+			"floatkey":  1.5,
+			"sliceKey":  []string{"a", "b"},
+		},
+		Annotations: []trace.Annotation{
+			{Time: start.Add(time.Millisecond), Message: "Annotation"},
+		},
+		MessageEvents: []trace.MessageEvent{
+			{Time: start.Add(2 * time.Millisecond), EventType: trace.MessageEventTypeRecv, MessageID: 1},
+		},
+		Status: trace.Status{Code: trace.StatusCodeNotFound, Message: "missing"},
+	}
+}
+
+func TestExportSpan(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	e := NewExporter(Options{
+		CollectorURL:  srv.URL,
+		LocalEndpoint: &Endpoint{ServiceName: "svc", IPv4: "10.0.0.1", Port: 8080},
+	})
+	e.ExportSpan(testSpanData())
+	if len(c.errs) > 0 {
+		t.Fatalf("bad request: %v", c.errs)
+	}
+	if len(c.batches) != 1 || len(c.batches[0]) != 1 {
+		t.Fatalf("got batches %v; want a single batch of one span", c.batches)
+	}
+
+	var want []map[string]interface{}
+	if err := json.Unmarshal([]byte(`[{
+		"traceId": "0102030405060708090a0b0c0d0e0f10",
+		"id": "0102030405060708",
+		"parentId": "0807060504030201",
+		"name": "/foo",
+		"kind": "SERVER",
+		"timestamp": 1500000000000000,
+		"duration": 24000,
+		"localEndpoint": {"serviceName": "svc", "ipv4": "10.0.0.1", "port": 8080},
+		"annotations": [
+			{"timestamp": 1500000000001000, "value": "Annotation"},
+			{"timestamp": 1500000000002000, "value": "RECV"}
+		],
+		"tags": {
+			"stringkey": "value",
+			"intkey": "42",
+			"boolkey": "true",
+			"floatkey": "1.5",
+			"sliceKey": "[\"a\",\"b\"]",
+			"error": "NOT_FOUND",
+			"opencensus.status_code": "NOT_FOUND",
+			"opencensus.status_description": "missing"
+		}
+	}]`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.batches[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("posted\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestExportSpan64BitTraceID(t *testing.T) {
+	sd := testSpanData()
+	sd.TraceID = trace.TraceID{8: 1, 15: 2}
+	sd.SpanKind = trace.SpanKindClient
+	sd.Status = trace.Status{}
+
+	s := zipkinSpan(sd, nil)
+	if got, want := s.TraceID, "0100000000000002"; got != want {
+		t.Errorf("TraceID = %q; want %q", got, want)
+	}
+	if got, want := s.Kind, "CLIENT"; got != want {
+		t.Errorf("Kind = %q; want %q", got, want)
+	}
+	if _, ok := s.Tags["error"]; ok {
+		t.Errorf("error tag set for an OK span: %v", s.Tags)
+	}
+}
+
+// startSpans ends n sampled spans, which the registered exporters receive.
+func startSpans(n int) {
+	for i := 0; i < n; i++ {
+		_, span := trace.StartSpan(context.Background(), "span", trace.WithSampler(trace.AlwaysSample()))
+		span.End()
+	}
+}
+
+func TestExportSpanBatching(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	trace.ApplyConfig(trace.Config{MaxExportBatchSize: 2, ExportBatchInterval: time.Hour})
+	defer trace.ApplyConfig(trace.Config{
+		MaxExportBatchSize:  trace.DefaultMaxExportBatchSize,
+		ExportBatchInterval: trace.DefaultExportBatchInterval,
+	})
+	e := NewExporter(Options{CollectorURL: srv.URL})
+	trace.RegisterExporter(e)
+	startSpans(5)
+	// Unregistering posts the last, incomplete batch.
+	trace.UnregisterExporter(e)
+
+	if got, want := c.batchSizes(), []int{2, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("batch sizes = %v; want %v", got, want)
+	}
+}
+
+func TestExportSpanBatchInterval(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	trace.ApplyConfig(trace.Config{ExportBatchInterval: 10 * time.Millisecond})
+	defer trace.ApplyConfig(trace.Config{ExportBatchInterval: trace.DefaultExportBatchInterval})
+	e := NewExporter(Options{CollectorURL: srv.URL})
+	trace.RegisterExporter(e)
+	defer trace.UnregisterExporter(e)
+	startSpans(1)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(c.batchSizes()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("span was not posted after the batch interval")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got, want := c.batchSizes(), []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("batch sizes = %v; want %v", got, want)
+	}
+}
+
+func TestExportSpanError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	trace.SetExportErrorHandler(func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+	defer trace.SetExportErrorHandler(nil)
+
+	e := NewExporter(Options{CollectorURL: srv.URL})
+	trace.RegisterExporter(e)
+	before := trace.ExportStats()
+	startSpans(2)
+	// Unregistering posts the pending batch.
+	trace.UnregisterExporter(e)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 1 {
+		t.Errorf("got %d errors; want 1", len(errs))
+	}
+	if got := trace.ExportStats().SpansExported - before.SpansExported; got != 0 {
+		t.Errorf("SpansExported increased by %d after a failed post; want 0", got)
+	}
+}
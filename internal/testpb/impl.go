@@ -64,12 +64,18 @@ func (s *testServer) Multiple(stream Foo_MultipleServer) error {
 
 // NewTestClient returns a new TestClient.
 func NewTestClient(l *testing.T) (client FooClient, cleanup func()) {
+	return NewTestClientWithHandlers(l, &ocgrpc.ClientHandler{}, &ocgrpc.ServerHandler{})
+}
+
+// NewTestClientWithHandlers returns a new TestClient whose client and server
+// use the given stats handlers.
+func NewTestClientWithHandlers(l *testing.T, ch *ocgrpc.ClientHandler, sh *ocgrpc.ServerHandler) (client FooClient, cleanup func()) {
 	// initialize server
 	listener, err := net.Listen("tcp", "localhost:0")
 	if err != nil {
 		l.Fatal(err)
 	}
-	server := grpc.NewServer(grpc.StatsHandler(&ocgrpc.ServerHandler{}))
+	server := grpc.NewServer(grpc.StatsHandler(sh))
 	RegisterFooServer(server, &testServer{})
 	go server.Serve(listener)
 
@@ -77,7 +83,7 @@ func NewTestClient(l *testing.T) (client FooClient, cleanup func()) {
 	clientConn, err := grpc.Dial(
 		listener.Addr().String(),
 		grpc.WithInsecure(),
-		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
+		grpc.WithStatsHandler(ch),
 		grpc.WithBlock())
 
 	if err != nil {
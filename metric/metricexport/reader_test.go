@@ -267,6 +267,74 @@ func TestNewIntervalReaderStartWithInvalidInterval(t *testing.T) {
 	}
 }
 
+func TestReadAndExportGauges(t *testing.T) {
+	r := metric.NewRegistry()
+	metricproducer.GlobalManager().AddProducer(r)
+	defer metricproducer.GlobalManager().DeleteProducer(r)
+
+	fg, err := r.AddFloat64Gauge("queue_load", metric.WithLabelKeys("queue"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fe, err := fg.GetEntry(metricdata.NewLabelValue("q1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fe.Set(1.5)
+	fe.Add(1)
+
+	dg, err := r.AddInt64DerivedGauge("queue_size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := int64(3)
+	if err := dg.UpsertEntry(func() int64 { return size }); err != nil {
+		t.Fatal(err)
+	}
+
+	exporter := &metricExporter{}
+	NewReader().ReadAndExport(exporter)
+
+	got := map[string]interface{}{}
+	for _, m := range exporter.metrics {
+		if len(m.TimeSeries) == 1 && len(m.TimeSeries[0].Points) == 1 {
+			got[m.Descriptor.Name] = m.TimeSeries[0].Points[0].Value
+		}
+	}
+	if v := got["queue_load"]; v != 2.5 {
+		t.Errorf("queue_load = %v; want 2.5", v)
+	}
+	if v := got["queue_size"]; v != int64(3) {
+		t.Errorf("queue_size = %v; want 3", v)
+	}
+}
+
+type fakeProducer struct {
+	metric *metricdata.Metric
+}
+
+func (p *fakeProducer) Read() []*metricdata.Metric {
+	return []*metricdata.Metric{p.metric}
+}
+
+func TestReadAndExportCustomProducer(t *testing.T) {
+	p := &fakeProducer{&metricdata.Metric{
+		Descriptor: metricdata.Descriptor{Name: "custom_metric", Type: metricdata.TypeGaugeInt64},
+	}}
+	metricproducer.GlobalManager().AddProducer(p)
+	defer metricproducer.GlobalManager().DeleteProducer(p)
+
+	exporter := &metricExporter{}
+	NewReader().ReadAndExport(exporter)
+
+	for _, m := range exporter.metrics {
+		if m == p.metric {
+			return
+		}
+	}
+	t.Errorf("metric from custom producer was not exported; got %v", exporter.metrics)
+}
+
 func checkExportedCount(exporter *metricExporter, wantCount int, t *testing.T) {
 	exporter.Lock()
 	defer exporter.Unlock()
@@ -29,6 +29,23 @@ type ClientHandler struct {
 	// StartOptions.SpanKind will always be set to trace.SpanKindClient
 	// for spans started by this handler.
 	StartOptions trace.StartOptions
+
+	// DisableTagPropagation stops the tags in the context of an RPC from
+	// being encoded into the gRPC metadata sent to the server, which saves
+	// the per-RPC encoding overhead when the server does not use them.
+	DisableTagPropagation bool
+
+	// TagMethod, if set, returns the value recorded in the
+	// grpc_client_method tag for the given full method name, which can be
+	// used to bucket methods and limit cardinality. If it returns false for
+	// keep, the RPC is traced but no stats are recorded for it.
+	TagMethod func(fullMethod string) (tagValue string, keep bool)
+
+	// FormatSpanName holds the function to use for generating the span name
+	// from the context and the full method name of the outgoing RPC. By default
+	// the name is the full method name with its slashes replaced by dots,
+	// for example "grpc.testing.TestService.UnaryCall".
+	FormatSpanName func(ctx context.Context, fullMethod string) string
 }
 
 // HandleConn exists to satisfy gRPC stats.Handler.
@@ -35,15 +35,19 @@ func (h *ClientHandler) statsTagRPC(ctx context.Context, info *stats.RPCTagInfo)
 		return ctx
 	}
 
-	d := &rpcData{
-		startTime: startTime,
-		method:    info.FullMethodName,
-	}
 	ts := tag.FromContext(ctx)
-	if ts != nil {
+	if ts != nil && !h.DisableTagPropagation {
 		encoded := tag.Encode(ts)
 		ctx = stats.SetTags(ctx, encoded)
 	}
 
+	method, keep := tagMethod(h.TagMethod, info.FullMethodName)
+	if !keep {
+		return ctx
+	}
+	d := &rpcData{
+		startTime: startTime,
+		method:    method,
+	}
 	return context.WithValue(ctx, rpcDataKey, d)
 }
@@ -104,6 +104,101 @@ func TestEndToEnd_Single(t *testing.T) {
 	}
 }
 
+func TestEndToEnd_TagPropagation(t *testing.T) {
+	tests := []struct {
+		name    string
+		client  *ocgrpc.ClientHandler
+		server  *ocgrpc.ServerHandler
+		wantTag bool
+	}{
+		{"default", &ocgrpc.ClientHandler{}, &ocgrpc.ServerHandler{}, true},
+		{"disabled on client", &ocgrpc.ClientHandler{DisableTagPropagation: true}, &ocgrpc.ServerHandler{}, false},
+		{"disabled on server", &ocgrpc.ClientHandler{}, &ocgrpc.ServerHandler{DisableTagPropagation: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &view.View{
+				Name:        "TestEndToEnd_TagPropagation/started_rpcs",
+				Measure:     ocgrpc.ServerStartedRPCs,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{keyAccountId},
+			}
+			if err := view.Register(v); err != nil {
+				t.Fatal(err)
+			}
+			defer view.Unregister(v)
+
+			client, done := testpb.NewTestClientWithHandlers(t, tt.client, tt.server)
+			defer done()
+
+			ctx, _ := tag.New(context.Background(), tag.Insert(keyAccountId, "abc123"))
+			if _, err := client.Single(ctx, &testpb.FooRequest{}); err != nil {
+				t.Fatal(err)
+			}
+
+			rows, err := view.RetrieveData(v.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != 1 {
+				t.Fatalf("got %d rows; want 1", len(rows))
+			}
+			var want []tag.Tag
+			if tt.wantTag {
+				want = []tag.Tag{{Key: keyAccountId, Value: "abc123"}}
+			}
+			if got := rows[0].Tags; !reflect.DeepEqual(got, want) {
+				t.Errorf("server row tags = %v; want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEndToEnd_TagMethod(t *testing.T) {
+	other := func(string) (string, bool) { return "other", true }
+	drop := func(string) (string, bool) { return "", false }
+	tests := []struct {
+		name      string
+		tagMethod func(string) (string, bool)
+		wantRows  int
+	}{
+		{"collapse", other, 1},
+		{"drop", drop, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := []*view.View{ocgrpc.ClientStartedRPCsView, ocgrpc.ServerStartedRPCsView}
+			if err := view.Register(views...); err != nil {
+				t.Fatal(err)
+			}
+			defer view.Unregister(views...)
+
+			client, done := testpb.NewTestClientWithHandlers(t,
+				&ocgrpc.ClientHandler{TagMethod: tt.tagMethod},
+				&ocgrpc.ServerHandler{TagMethod: tt.tagMethod})
+			defer done()
+
+			if _, err := client.Single(context.Background(), &testpb.FooRequest{}); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, v := range views {
+				rows, err := view.RetrieveData(v.Name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(rows) != tt.wantRows {
+					t.Fatalf("%s: got %d rows; want %d", v.Name, len(rows), tt.wantRows)
+				}
+			}
+			if tt.wantRows > 0 {
+				checkCount(t, ocgrpc.ClientStartedRPCsView, 1, tag.Tag{Key: ocgrpc.KeyClientMethod, Value: "other"})
+				checkCount(t, ocgrpc.ServerStartedRPCsView, 1, tag.Tag{Key: ocgrpc.KeyServerMethod, Value: "other"})
+			}
+		})
+	}
+}
+
 func TestEndToEnd_Stream(t *testing.T) {
 	view.Register(ocgrpc.DefaultClientViews...)
 	defer view.Unregister(ocgrpc.DefaultClientViews...)
@@ -52,6 +52,24 @@ type ServerHandler struct {
 	// StartOptions.SpanKind will always be set to trace.SpanKindServer
 	// for spans started by this handler.
 	StartOptions trace.StartOptions
+
+	// DisableTagPropagation ignores the tags sent by clients in the gRPC
+	// metadata, so they are neither decoded nor added to the context of the
+	// RPC. Public-facing servers may set it to keep callers from adding tags
+	// to the recorded stats.
+	DisableTagPropagation bool
+
+	// TagMethod, if set, returns the value recorded in the
+	// grpc_server_method tag for the given full method name, which can be
+	// used to bucket methods and limit cardinality. If it returns false for
+	// keep, the RPC is traced but no stats are recorded for it.
+	TagMethod func(fullMethod string) (tagValue string, keep bool)
+
+	// FormatSpanName holds the function to use for generating the span name
+	// from the context and the full method name of the incoming RPC. By default
+	// the name is the full method name with its slashes replaced by dots,
+	// for example "grpc.testing.TestService.UnaryCall".
+	FormatSpanName func(ctx context.Context, fullMethod string) string
 }
 
 var _ stats.Handler = (*ServerHandler)(nil)
@@ -35,19 +35,26 @@ func (h *ServerHandler) statsTagRPC(ctx context.Context, info *stats.RPCTagInfo)
 		}
 		return ctx
 	}
+	propagated := h.extractPropagatedTags(ctx)
+	ctx = tag.NewContext(ctx, propagated)
+	method, keep := tagMethod(h.TagMethod, info.FullMethodName)
+	if !keep {
+		return ctx
+	}
 	d := &rpcData{
 		startTime: startTime,
-		method:    info.FullMethodName,
+		method:    method,
 	}
-	propagated := h.extractPropagatedTags(ctx)
-	ctx = tag.NewContext(ctx, propagated)
-	ctx, _ = tag.New(ctx, tag.Upsert(KeyServerMethod, methodName(info.FullMethodName)))
+	ctx, _ = tag.New(ctx, tag.Upsert(KeyServerMethod, method))
 	return context.WithValue(ctx, rpcDataKey, d)
 }
 
 // extractPropagatedTags creates a new tag map containing the tags extracted from the
 // gRPC metadata.
 func (h *ServerHandler) extractPropagatedTags(ctx context.Context) *tag.Map {
+	if h.DisableTagPropagation {
+		return nil
+	}
 	buf := stats.Tags(ctx)
 	if buf == nil {
 		return nil
@@ -53,8 +53,8 @@ type rpcData struct {
 // The following variables define the default hard-coded auxiliary data used by
 // both the default GRPC client and GRPC server metrics.
 var (
-	DefaultBytesDistribution        = view.Distribution(1024, 2048, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864, 268435456, 1073741824, 4294967296)
-	DefaultMillisecondsDistribution = view.Distribution(0.01, 0.05, 0.1, 0.3, 0.6, 0.8, 1, 2, 3, 4, 5, 6, 8, 10, 13, 16, 20, 25, 30, 40, 50, 65, 80, 100, 130, 160, 200, 250, 300, 400, 500, 650, 800, 1000, 2000, 5000, 10000, 20000, 50000, 100000)
+	DefaultBytesDistribution        = view.DefaultSizeDistribution()
+	DefaultMillisecondsDistribution = view.DefaultLatencyDistribution()
 	DefaultMessageCountDistribution = view.Distribution(1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096, 8192, 16384, 32768, 65536)
 )
 
@@ -79,6 +79,14 @@ func methodName(fullname string) string {
 	return strings.TrimLeft(fullname, "/")
 }
 
+// tagMethod returns the method tag value for fullname using f, if set.
+func tagMethod(f func(string) (string, bool), fullname string) (string, bool) {
+	if f == nil {
+		return methodName(fullname), true
+	}
+	return f(fullname)
+}
+
 // statsHandleRPC processes the RPC events.
 func statsHandleRPC(ctx context.Context, s stats.RPCStats) {
 	switch st := s.(type) {
@@ -103,6 +111,7 @@ func handleRPCBegin(ctx context.Context, s *stats.Begin) {
 		if grpclog.V(2) {
 			grpclog.Infoln("Failed to retrieve *rpcData from context.")
 		}
+		return
 	}
 
 	if s.IsClient() {
@@ -34,8 +34,7 @@ const traceContextKey = "grpc-trace-bin"
 // It returns ctx with the new trace span added and a serialization of the
 // SpanContext added to the outgoing gRPC metadata.
 func (c *ClientHandler) traceTagRPC(ctx context.Context, rti *stats.RPCTagInfo) context.Context {
-	name := strings.TrimPrefix(rti.FullMethodName, "/")
-	name = strings.Replace(name, "/", ".", -1)
+	name := formatSpanName(ctx, c.FormatSpanName, rti.FullMethodName)
 	ctx, span := trace.StartSpan(ctx, name,
 		trace.WithSampler(c.StartOptions.Sampler),
 		trace.WithSpanKind(trace.SpanKindClient)) // span is ended by traceHandleRPC
@@ -51,8 +50,7 @@ func (c *ClientHandler) traceTagRPC(ctx context.Context, rti *stats.RPCTagInfo)
 // It returns ctx, with the new trace span added.
 func (s *ServerHandler) traceTagRPC(ctx context.Context, rti *stats.RPCTagInfo) context.Context {
 	md, _ := metadata.FromIncomingContext(ctx)
-	name := strings.TrimPrefix(rti.FullMethodName, "/")
-	name = strings.Replace(name, "/", ".", -1)
+	name := formatSpanName(ctx, s.FormatSpanName, rti.FullMethodName)
 	traceContext := md[traceContextKey]
 	var (
 		parent     trace.SpanContext
@@ -81,6 +79,18 @@ func (s *ServerHandler) traceTagRPC(ctx context.Context, rti *stats.RPCTagInfo)
 	return ctx
 }
 
+// formatSpanName returns the name of the span started for the RPC to
+// fullMethod, using format if it is set. The default name is the full method
+// name with its leading slash removed and the other slashes replaced by
+// dots, for example "grpc.testing.TestService.UnaryCall".
+func formatSpanName(ctx context.Context, format func(context.Context, string) string, fullMethod string) string {
+	if format != nil {
+		return format(ctx, fullMethod)
+	}
+	name := strings.TrimPrefix(fullMethod, "/")
+	return strings.Replace(name, "/", ".", -1)
+}
+
 func traceHandleRPC(ctx context.Context, rs stats.RPCStats) {
 	span := trace.FromContext(ctx)
 	// TODO: compressed and uncompressed sizes are not populated in every message.
@@ -97,11 +107,56 @@ func traceHandleRPC(ctx context.Context, rs stats.RPCStats) {
 		if rs.Error != nil {
 			s, ok := status.FromError(rs.Error)
 			if ok {
-				span.SetStatus(trace.Status{Code: int32(s.Code()), Message: s.Message()})
+				span.SetStatus(trace.Status{Code: TraceStatusFromGRPCCode(s.Code()).Code, Message: s.Message()})
 			} else {
-				span.SetStatus(trace.Status{Code: int32(codes.Internal), Message: rs.Error.Error()})
+				span.SetStatus(trace.Status{Code: trace.StatusCodeInternal, Message: rs.Error.Error()})
 			}
 		}
 		span.End()
 	}
 }
+
+// TraceStatusFromGRPCCode returns the trace status for the gRPC status code c.
+// Codes outside of the canonical set map to UNKNOWN.
+func TraceStatusFromGRPCCode(c codes.Code) trace.Status {
+	var code int32
+	switch c {
+	case codes.OK:
+		code = trace.StatusCodeOK
+	case codes.Canceled:
+		code = trace.StatusCodeCancelled
+	case codes.Unknown:
+		code = trace.StatusCodeUnknown
+	case codes.InvalidArgument:
+		code = trace.StatusCodeInvalidArgument
+	case codes.DeadlineExceeded:
+		code = trace.StatusCodeDeadlineExceeded
+	case codes.NotFound:
+		code = trace.StatusCodeNotFound
+	case codes.AlreadyExists:
+		code = trace.StatusCodeAlreadyExists
+	case codes.PermissionDenied:
+		code = trace.StatusCodePermissionDenied
+	case codes.ResourceExhausted:
+		code = trace.StatusCodeResourceExhausted
+	case codes.FailedPrecondition:
+		code = trace.StatusCodeFailedPrecondition
+	case codes.Aborted:
+		code = trace.StatusCodeAborted
+	case codes.OutOfRange:
+		code = trace.StatusCodeOutOfRange
+	case codes.Unimplemented:
+		code = trace.StatusCodeUnimplemented
+	case codes.Internal:
+		code = trace.StatusCodeInternal
+	case codes.Unavailable:
+		code = trace.StatusCodeUnavailable
+	case codes.DataLoss:
+		code = trace.StatusCodeDataLoss
+	case codes.Unauthenticated:
+		code = trace.StatusCodeUnauthenticated
+	default:
+		code = trace.StatusCodeUnknown
+	}
+	return trace.Status{Code: code, Message: c.String()}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/stats"
 
@@ -45,3 +46,38 @@ func TestClientHandler_traceTagRPC(t *testing.T) {
 		t.Fatal("no metadata")
 	}
 }
+
+func TestTraceStatusFromGRPCCode(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int32
+	}{
+		{codes.OK, trace.StatusCodeOK},
+		{codes.Canceled, trace.StatusCodeCancelled},
+		{codes.Unknown, trace.StatusCodeUnknown},
+		{codes.InvalidArgument, trace.StatusCodeInvalidArgument},
+		{codes.DeadlineExceeded, trace.StatusCodeDeadlineExceeded},
+		{codes.NotFound, trace.StatusCodeNotFound},
+		{codes.AlreadyExists, trace.StatusCodeAlreadyExists},
+		{codes.PermissionDenied, trace.StatusCodePermissionDenied},
+		{codes.ResourceExhausted, trace.StatusCodeResourceExhausted},
+		{codes.FailedPrecondition, trace.StatusCodeFailedPrecondition},
+		{codes.Aborted, trace.StatusCodeAborted},
+		{codes.OutOfRange, trace.StatusCodeOutOfRange},
+		{codes.Unimplemented, trace.StatusCodeUnimplemented},
+		{codes.Internal, trace.StatusCodeInternal},
+		{codes.Unavailable, trace.StatusCodeUnavailable},
+		{codes.DataLoss, trace.StatusCodeDataLoss},
+		{codes.Unauthenticated, trace.StatusCodeUnauthenticated},
+		{codes.Code(100), trace.StatusCodeUnknown},
+	}
+	for _, tt := range tests {
+		got := TraceStatusFromGRPCCode(tt.code)
+		if got.Code != tt.want {
+			t.Errorf("TraceStatusFromGRPCCode(%v).Code = %d; want %d", tt.code, got.Code, tt.want)
+		}
+		if got.Message != tt.code.String() {
+			t.Errorf("TraceStatusFromGRPCCode(%v).Message = %q; want %q", tt.code, got.Message, tt.code.String())
+		}
+	}
+}
@@ -17,10 +17,12 @@ package ocgrpc_test
 import (
 	"context"
 	"io"
+	"path"
 	"testing"
 	"time"
 
 	"go.opencensus.io/internal/testpb"
+	"go.opencensus.io/plugin/ocgrpc"
 	"go.opencensus.io/trace"
 )
 
@@ -199,6 +201,38 @@ func TestSingleFail(t *testing.T) {
 	}
 }
 
+func TestFormatSpanName(t *testing.T) {
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.AlwaysSample()})
+	te := testExporter{make(chan *trace.SpanData)}
+	trace.RegisterExporter(&te)
+	defer trace.UnregisterExporter(&te)
+
+	format := func(side string) func(context.Context, string) string {
+		return func(ctx context.Context, fullMethod string) string {
+			return side + ":" + path.Base(fullMethod)
+		}
+	}
+	client, cleanup := testpb.NewTestClientWithHandlers(t,
+		&ocgrpc.ClientHandler{FormatSpanName: format("client")},
+		&ocgrpc.ServerHandler{FormatSpanName: format("server")})
+
+	if _, err := client.Single(context.Background(), &testpb.FooRequest{}); err != nil {
+		t.Fatalf("Single() = %v; want no error", err)
+	}
+	cleanup()
+
+	for i := 0; i < 2; i++ {
+		s := <-te.ch
+		want := "client:Single"
+		if s.SpanKind == trace.SpanKindServer {
+			want = "server:Single"
+		}
+		if s.Name != want {
+			t.Errorf("span name = %q; want %q", s.Name, want)
+		}
+	}
+}
+
 func checkSpanData(t *testing.T, s1, s2 *trace.SpanData, methodName string, success bool) {
 	t.Helper()
 
@@ -48,7 +48,9 @@ type Transport struct {
 	StartOptions trace.StartOptions
 
 	// GetStartOptions allows to set start options per request. If set,
-	// StartOptions is going to be ignored.
+	// StartOptions is going to be ignored. For example, it can return
+	// trace.NeverSample() as the Sampler when the span in the request
+	// context is not sampled, so no span is recorded for the request.
 	GetStartOptions func(*http.Request) trace.StartOptions
 
 	// NameFromRequest holds the function to use for generating the span name
@@ -56,12 +58,29 @@ type Transport struct {
 	// name equals the URL Path.
 	FormatSpanName func(*http.Request) string
 
+	// TagPath, if set, transforms the URL path before it is recorded in the
+	// http.path and http.url span attributes and the path stats tags, and
+	// before it is used as the default span name. It can be used to redact identifiers from
+	// paths in order to limit cardinality.
+	TagPath func(path string) string
+
 	// NewClientTrace may be set to a function allowing the current *trace.Span
 	// to be annotated with HTTP request event information emitted by the
 	// httptrace package.
 	NewClientTrace func(*http.Request, *trace.Span) *httptrace.ClientTrace
 
-	// TODO: Implement tag propagation for HTTP.
+	// TagPropagationHeader, if set, names the request header in which the
+	// tags of the request context are propagated, base64 encoded in the
+	// format of tag.Encode. Set Handler.TagPropagationHeader to the same
+	// header on the server to continue the tags there.
+	TagPropagationHeader string
+
+	// MessageEvents, if true, adds a message send event with the number of
+	// bytes of the request body and a message receive event with the number
+	// of bytes of the response body to the span of each request, as the
+	// gRPC plugin does for messages. The response size is counted after
+	// decompression by Base.
+	MessageEvents bool
 }
 
 // RoundTrip implements http.RoundTripper, delegating to Base and recording stats and traces for the request.
@@ -78,6 +97,11 @@ func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	spanNameFormatter := t.FormatSpanName
 	if spanNameFormatter == nil {
 		spanNameFormatter = spanNameFromURL
+		if t.TagPath != nil {
+			spanNameFormatter = func(req *http.Request) string {
+				return t.TagPath(spanNameFromURL(req))
+			}
+		}
 	}
 
 	startOpts := t.StartOptions
@@ -85,6 +109,9 @@ func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		startOpts = t.GetStartOptions(req)
 	}
 
+	if t.TagPropagationHeader != "" {
+		rt = &tagTransport{base: rt, header: t.TagPropagationHeader}
+	}
 	rt = &traceTransport{
 		base:   rt,
 		format: format,
@@ -94,8 +121,10 @@ func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		},
 		formatSpanName: spanNameFormatter,
 		newClientTrace: t.NewClientTrace,
+		tagPath:        t.TagPath,
+		messageEvents:  t.MessageEvents,
 	}
-	rt = statsTransport{base: rt}
+	rt = statsTransport{base: rt, tagPath: t.TagPath}
 	return rt.RoundTrip(req)
 }
 
@@ -28,16 +28,18 @@ import (
 
 // statsTransport is an http.RoundTripper that collects stats for the outgoing requests.
 type statsTransport struct {
-	base http.RoundTripper
+	base    http.RoundTripper
+	tagPath func(string) string
 }
 
 // RoundTrip implements http.RoundTripper, delegating to Base and recording stats for the request.
 func (t statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := formatPath(t.tagPath, req.URL.Path)
 	ctx, _ := tag.New(req.Context(),
 		tag.Upsert(KeyClientHost, req.Host),
 		tag.Upsert(Host, req.Host),
-		tag.Upsert(KeyClientPath, req.URL.Path),
-		tag.Upsert(Path, req.URL.Path),
+		tag.Upsert(KeyClientPath, path),
+		tag.Upsert(Path, path),
 		tag.Upsert(KeyClientMethod, req.Method),
 		tag.Upsert(Method, req.Method))
 	req = req.WithContext(ctx)
@@ -46,10 +48,15 @@ func (t statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		ctx:   ctx,
 	}
 	if req.Body == nil {
-		// TODO: Handle cases where ContentLength is not set.
 		track.reqSize = -1
-	} else if req.ContentLength > 0 {
-		track.reqSize = req.ContentLength
+	} else if req.Body != http.NoBody {
+		if req.ContentLength > 0 {
+			track.reqSize = req.ContentLength
+		}
+		// Count the bytes actually sent, as ContentLength is not set for
+		// streaming bodies.
+		track.reqBody = &countingBody{body: req.Body}
+		req.Body = wrappedBody(track.reqBody, req.Body)
 	}
 	stats.Record(ctx, ClientRequestCount.M(1))
 
@@ -89,6 +96,7 @@ type tracker struct {
 	respSize          int64
 	respContentLength int64
 	reqSize           int64
+	reqBody           *countingBody
 	start             time.Time
 	body              io.ReadCloser
 	statusCode        int
@@ -100,19 +108,20 @@ var _ io.ReadCloser = (*tracker)(nil)
 func (t *tracker) end() {
 	t.endOnce.Do(func() {
 		latencyMs := float64(time.Since(t.start)) / float64(time.Millisecond)
+		reqSize := t.reqBody.size(t.reqSize)
 		respSize := t.respSize
 		if t.respSize == 0 && t.respContentLength > 0 {
 			respSize = t.respContentLength
 		}
 		m := []stats.Measurement{
-			ClientSentBytes.M(t.reqSize),
+			ClientSentBytes.M(reqSize),
 			ClientReceivedBytes.M(respSize),
 			ClientRoundtripLatency.M(latencyMs),
 			ClientLatency.M(latencyMs),
 			ClientResponseBytes.M(t.respSize),
 		}
-		if t.reqSize >= 0 {
-			m = append(m, ClientRequestBytes.M(t.reqSize))
+		if reqSize >= 0 {
+			m = append(m, ClientRequestBytes.M(reqSize))
 		}
 
 		stats.RecordWithTags(t.ctx, []tag.Mutator{
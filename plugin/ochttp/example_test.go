@@ -75,3 +75,25 @@ func ExampleHandler_mux() {
 		Propagation: &b3.HTTPFormat{},
 	}))
 }
+
+func ExampleWithRouteTag() {
+	// import (
+	// 		"go.opencensus.io/plugin/ochttp"
+	//		"go.opencensus.io/stats/view"
+	// )
+
+	// Record latency by route template rather than by concrete path.
+	if err := view.Register(&view.View{
+		Name:        "httpserver_latency_by_route",
+		Measure:     ochttp.ServerLatency,
+		Aggregation: ochttp.DefaultLatencyDistribution,
+		TagKeys:     []tag.Key{ochttp.KeyServerRoute},
+	}); err != nil {
+		log.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	// Requests to /users/12345 are recorded, and their spans named, as /users/{id}.
+	mux.Handle("/users/", ochttp.WithRouteTag(usersHandler, "/users/{id}"))
+	log.Fatal(http.ListenAndServe("localhost:8080", &ochttp.Handler{Handler: mux}))
+}
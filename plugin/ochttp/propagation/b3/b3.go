@@ -20,6 +20,7 @@ package b3 // import "go.opencensus.io/plugin/ochttp/propagation/b3"
 import (
 	"encoding/hex"
 	"net/http"
+	"strings"
 
 	"go.opencensus.io/trace"
 	"go.opencensus.io/trace/propagation"
@@ -30,6 +31,11 @@ const (
 	TraceIDHeader = "X-B3-TraceId"
 	SpanIDHeader  = "X-B3-SpanId"
 	SampledHeader = "X-B3-Sampled"
+
+	// SingleHeader is the header of the single-header B3 format, whose value
+	// is {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}, where the last
+	// two fields are optional.
+	SingleHeader = "b3"
 )
 
 // HTTPFormat implements propagation.HTTPFormat to propagate
@@ -40,12 +46,22 @@ const (
 // header will be the direct children of the client-side span.
 // Similarly, receiver of the outgoing spans should use client-side
 // span created by OpenCensus as the parent.
-type HTTPFormat struct{}
+//
+// Incoming requests carrying the single b3 header are parsed from it in
+// preference to the multiple X-B3-* headers.
+type HTTPFormat struct {
+	// InjectSingleHeader makes SpanContextToRequest write the single b3
+	// header instead of the multiple X-B3-* headers.
+	InjectSingleHeader bool
+}
 
 var _ propagation.HTTPFormat = (*HTTPFormat)(nil)
 
 // SpanContextFromRequest extracts a B3 span context from incoming requests.
 func (f *HTTPFormat) SpanContextFromRequest(req *http.Request) (sc trace.SpanContext, ok bool) {
+	if h := req.Header.Get(SingleHeader); h != "" {
+		return ParseSingleHeader(h)
+	}
 	tid, ok := ParseTraceID(req.Header.Get(TraceIDHeader))
 	if !ok {
 		return trace.SpanContext{}, false
@@ -108,8 +124,64 @@ func ParseSampled(sampled string) (trace.TraceOptions, bool) {
 	}
 }
 
+// ParseSingleHeader parses the value of the single b3 header. The sampling
+// state may be "1", "0" or "d" (debug, which implies sampled). The parent
+// span ID, if present, is validated but otherwise ignored.
+func ParseSingleHeader(h string) (trace.SpanContext, bool) {
+	parts := strings.Split(h, "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		// A lone sampling state carries no span context.
+		return trace.SpanContext{}, false
+	}
+	if l := len(parts[0]); l != 16 && l != 32 {
+		return trace.SpanContext{}, false
+	}
+	tid, ok := ParseTraceID(parts[0])
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+	if len(parts[1]) != 16 {
+		return trace.SpanContext{}, false
+	}
+	sid, ok := ParseSpanID(parts[1])
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+	var sampled trace.TraceOptions
+	if len(parts) > 2 {
+		switch parts[2] {
+		case "1", "d":
+			sampled = trace.TraceOptions(1)
+		case "0":
+		default:
+			return trace.SpanContext{}, false
+		}
+	}
+	if len(parts) > 3 {
+		if len(parts[3]) != 16 {
+			return trace.SpanContext{}, false
+		}
+		if _, ok := ParseSpanID(parts[3]); !ok {
+			return trace.SpanContext{}, false
+		}
+	}
+	return trace.SpanContext{
+		TraceID:      tid,
+		SpanID:       sid,
+		TraceOptions: sampled,
+	}, true
+}
+
 // SpanContextToRequest modifies the given request to include B3 headers.
 func (f *HTTPFormat) SpanContextToRequest(sc trace.SpanContext, req *http.Request) {
+	if f.InjectSingleHeader {
+		sampled := "0"
+		if sc.IsSampled() {
+			sampled = "1"
+		}
+		req.Header.Set(SingleHeader, hex.EncodeToString(sc.TraceID[:])+"-"+hex.EncodeToString(sc.SpanID[:])+"-"+sampled)
+		return
+	}
 	req.Header.Set(TraceIDHeader, hex.EncodeToString(sc.TraceID[:]))
 	req.Header.Set(SpanIDHeader, hex.EncodeToString(sc.SpanID[:]))
 
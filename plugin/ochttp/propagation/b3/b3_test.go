@@ -183,6 +183,128 @@ func TestHTTPFormat_FromRequest(t *testing.T) {
 	}
 }
 
+func TestHTTPFormat_FromRequestSingleHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		wantSc trace.SpanContext
+		wantOk bool
+	}{
+		{
+			name:   "128-bit trace ID; sampled=1",
+			header: "463ac35c9f6413ad48485a3953bb6124-0020000000000001-1",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{70, 58, 195, 92, 159, 100, 19, 173, 72, 72, 90, 57, 83, 187, 97, 36},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "64-bit trace ID; sampled=0",
+			header: "48485a3953bb6124-0020000000000001-0",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 72, 72, 90, 57, 83, 187, 97, 36},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(0),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "debug sampling state with parent span ID",
+			header: "463ac35c9f6413ad48485a3953bb6124-0020000000000001-d-0020000000000002",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{70, 58, 195, 92, 159, 100, 19, 173, 72, 72, 90, 57, 83, 187, 97, 36},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "no sampling state",
+			header: "463ac35c9f6413ad48485a3953bb6124-0020000000000001",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{70, 58, 195, 92, 159, 100, 19, 173, 72, 72, 90, 57, 83, 187, 97, 36},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(0),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "sampling state only",
+			header: "1",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "invalid sampling state",
+			header: "463ac35c9f6413ad48485a3953bb6124-0020000000000001-true",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "short trace ID",
+			header: "463ac35c9f6413ad-0020000000001-1",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "invalid trace ID length",
+			header: "3ac35c9f6413ad48485a3953bb6124-0020000000000001-1",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "invalid parent span ID",
+			header: "463ac35c9f6413ad48485a3953bb6124-0020000000000001-1-002",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			req.Header.Set(SingleHeader, tt.header)
+			// The single header takes precedence over the multiple headers.
+			req.Header.Set(TraceIDHeader, "0000000000000000000000000000000a")
+			req.Header.Set(SpanIDHeader, "000000000000000a")
+			req.Header.Set(SampledHeader, "1")
+
+			f := &HTTPFormat{}
+			sc, ok := f.SpanContextFromRequest(req)
+			if ok != tt.wantOk {
+				t.Errorf("HTTPFormat.SpanContextFromRequest() got ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(sc, tt.wantSc) {
+				t.Errorf("HTTPFormat.SpanContextFromRequest() got span context = %v, want %v", sc, tt.wantSc)
+			}
+		})
+	}
+}
+
+func TestHTTPFormat_ToRequestSingleHeader(t *testing.T) {
+	sc := trace.SpanContext{
+		TraceID:      trace.TraceID{70, 58, 195, 92, 159, 100, 19, 173, 72, 72, 90, 57, 83, 187, 97, 36},
+		SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+		TraceOptions: trace.TraceOptions(1),
+	}
+	f := &HTTPFormat{InjectSingleHeader: true}
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	f.SpanContextToRequest(sc, req)
+
+	if got, want := req.Header.Get(SingleHeader), "463ac35c9f6413ad48485a3953bb6124-0020000000000001-1"; got != want {
+		t.Errorf("req.Header.Get(%q) = %q; want %q", SingleHeader, got, want)
+	}
+	if got := req.Header.Get(TraceIDHeader); got != "" {
+		t.Errorf("req.Header.Get(%q) = %q; want empty", TraceIDHeader, got)
+	}
+
+	got, ok := f.SpanContextFromRequest(req)
+	if !ok || !reflect.DeepEqual(got, sc) {
+		t.Errorf("round trip: got %v, %v; want %v, true", got, ok, sc)
+	}
+}
+
 func TestHTTPFormat_ToRequest(t *testing.T) {
 	tests := []struct {
 		name        string
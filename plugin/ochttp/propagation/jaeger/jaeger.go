@@ -0,0 +1,125 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jaeger contains a propagation.HTTPFormat implementation
+// for the Jaeger uber-trace-id header. See
+// https://www.jaegertracing.io/docs/latest/client-libraries/#propagation-format
+// for more details.
+package jaeger // import "go.opencensus.io/plugin/ochttp/propagation/jaeger"
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
+)
+
+// TraceContextHeader is the header carrying the Jaeger span context, whose
+// value is {trace-id}:{span-id}:{parent-span-id}:{flags}.
+const TraceContextHeader = "uber-trace-id"
+
+// Flags of the uber-trace-id header.
+const (
+	flagSampled = 0x1
+	flagDebug   = 0x2
+)
+
+// HTTPFormat implements propagation.HTTPFormat to propagate traces in the
+// Jaeger uber-trace-id header. The parent span ID of incoming headers is
+// ignored, and outgoing headers carry the deprecated value 0 in its place.
+type HTTPFormat struct{}
+
+var _ propagation.HTTPFormat = (*HTTPFormat)(nil)
+
+// SpanContextFromRequest extracts a Jaeger span context from incoming requests.
+func (f *HTTPFormat) SpanContextFromRequest(req *http.Request) (sc trace.SpanContext, ok bool) {
+	h := req.Header.Get(TraceContextHeader)
+	if h == "" {
+		return trace.SpanContext{}, false
+	}
+	return ParseHeader(h)
+}
+
+// ParseHeader parses the value of the uber-trace-id header. Trace IDs of up
+// to 64 bits are placed in the low 8 bytes of the TraceID. The debug flag
+// implies that the trace is sampled.
+func ParseHeader(h string) (trace.SpanContext, bool) {
+	// Some Jaeger clients URL-encode the header value.
+	if strings.Contains(h, "%") {
+		var err error
+		if h, err = url.PathUnescape(h); err != nil {
+			return trace.SpanContext{}, false
+		}
+	}
+	parts := strings.Split(h, ":")
+	if len(parts) != 4 {
+		return trace.SpanContext{}, false
+	}
+	var sc trace.SpanContext
+	if !parseID(sc.TraceID[:], parts[0]) || sc.TraceID == (trace.TraceID{}) {
+		return trace.SpanContext{}, false
+	}
+	if !parseID(sc.SpanID[:], parts[1]) || sc.SpanID == (trace.SpanID{}) {
+		return trace.SpanContext{}, false
+	}
+	var parent trace.SpanID
+	if !parseID(parent[:], parts[2]) {
+		return trace.SpanContext{}, false
+	}
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	if flags&(flagSampled|flagDebug) != 0 {
+		sc.TraceOptions = trace.TraceOptions(1)
+	}
+	return sc, true
+}
+
+// parseID decodes the hex string s, which may omit leading zeros, into the
+// low bytes of dst.
+func parseID(dst []byte, s string) bool {
+	if s == "" || len(s) > 2*len(dst) {
+		return false
+	}
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return false
+	}
+	copy(dst[len(dst)-len(b):], b)
+	return true
+}
+
+// SpanContextToRequest modifies the given request to include the
+// uber-trace-id header. Trace IDs whose high 8 bytes are zero are written
+// as 64-bit IDs.
+func (f *HTTPFormat) SpanContextToRequest(sc trace.SpanContext, req *http.Request) {
+	traceID := sc.TraceID[:]
+	if bytes.Equal(traceID[:8], make([]byte, 8)) {
+		traceID = traceID[8:]
+	}
+	flags := "0"
+	if sc.IsSampled() {
+		flags = "1"
+	}
+	req.Header.Set(TraceContextHeader, hex.EncodeToString(traceID)+":"+hex.EncodeToString(sc.SpanID[:])+":0:"+flags)
+}
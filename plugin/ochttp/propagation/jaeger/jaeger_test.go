@@ -0,0 +1,142 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"go.opencensus.io/trace"
+)
+
+func TestHTTPFormat_FromRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		wantSc trace.SpanContext
+		wantOk bool
+	}{
+		{
+			name:   "128-bit trace ID; sampled",
+			header: "463ac35c9f6413ad48485a3953bb6124:0020000000000001:0:1",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{70, 58, 195, 92, 159, 100, 19, 173, 72, 72, 90, 57, 83, 187, 97, 36},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "64-bit trace ID; not sampled",
+			header: "463ac35c9f6413ad:0020000000000001:0:0",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 70, 58, 195, 92, 159, 100, 19, 173},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(0),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "short trace and span IDs",
+			header: "3c9f6413ad:abc:0:1",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x3c, 0x9f, 0x64, 0x13, 0xad},
+				SpanID:       trace.SpanID{0, 0, 0, 0, 0, 0, 0x0a, 0xbc},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "debug flag implies sampled",
+			header: "463ac35c9f6413ad:0020000000000001:0:2",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 70, 58, 195, 92, 159, 100, 19, 173},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "URL-encoded with parent span ID",
+			header: "463ac35c9f6413ad%3A0020000000000001%3A0020000000000002%3A3",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 70, 58, 195, 92, 159, 100, 19, 173},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantOk: true,
+		},
+		{name: "missing header", header: ""},
+		{name: "too few fields", header: "463ac35c9f6413ad:0020000000000001:1"},
+		{name: "trace ID too long", header: "463ac35c9f6413ad48485a3953bb612401:0020000000000001:0:1"},
+		{name: "zero trace ID", header: "0:0020000000000001:0:1"},
+		{name: "zero span ID", header: "463ac35c9f6413ad:0:0:1"},
+		{name: "non-hex span ID", header: "463ac35c9f6413ad:zz20000000000001:0:1"},
+		{name: "bad flags", header: "463ac35c9f6413ad:0020000000000001:0:x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			if tt.header != "" {
+				req.Header.Set(TraceContextHeader, tt.header)
+			}
+			f := &HTTPFormat{}
+			sc, ok := f.SpanContextFromRequest(req)
+			if ok != tt.wantOk {
+				t.Errorf("SpanContextFromRequest() ok = %v; want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(sc, tt.wantSc) {
+				t.Errorf("SpanContextFromRequest() sc = %v; want %v", sc, tt.wantSc)
+			}
+		})
+	}
+}
+
+func TestHTTPFormat_ToRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		sc         trace.SpanContext
+		wantHeader string
+	}{
+		{
+			name: "128-bit trace ID; sampled",
+			sc: trace.SpanContext{
+				TraceID:      trace.TraceID{70, 58, 195, 92, 159, 100, 19, 173, 72, 72, 90, 57, 83, 187, 97, 36},
+				SpanID:       trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantHeader: "463ac35c9f6413ad48485a3953bb6124:0020000000000001:0:1",
+		},
+		{
+			name: "64-bit trace ID; not sampled",
+			sc: trace.SpanContext{
+				TraceID: trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 70, 58, 195, 92, 159, 100, 19, 173},
+				SpanID:  trace.SpanID{0, 32, 0, 0, 0, 0, 0, 1},
+			},
+			wantHeader: "463ac35c9f6413ad:0020000000000001:0:0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			f := &HTTPFormat{}
+			f.SpanContextToRequest(tt.sc, req)
+			if got := req.Header.Get(TraceContextHeader); got != tt.wantHeader {
+				t.Errorf("SpanContextToRequest() header = %q; want %q", got, tt.wantHeader)
+			}
+		})
+	}
+}
@@ -96,8 +96,11 @@ func (f *HTTPFormat) SpanContextFromHeaders(tp string, ts string) (sc trace.Span
 	}
 	copy(sc.SpanID[:], sid)
 
+	if len(sections[3]) != 2 {
+		return trace.SpanContext{}, false
+	}
 	opts, err := hex.DecodeString(sections[3])
-	if err != nil || len(opts) < 1 {
+	if err != nil {
 		return trace.SpanContext{}, false
 	}
 	sc.TraceOptions = trace.TraceOptions(opts[0])
@@ -85,6 +85,64 @@ func TestHTTPFormat_FromRequest(t *testing.T) {
 			wantSc: trace.SpanContext{},
 			wantOk: false,
 		},
+		{
+			name:   "invalid version ff",
+			header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "invalid version length",
+			header: "000-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "extra fields in version 00",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "future version with extra fields",
+			header: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			wantSc: trace.SpanContext{
+				TraceID:      trace.TraceID{75, 249, 47, 53, 119, 179, 77, 166, 163, 206, 146, 157, 14, 14, 71, 54},
+				SpanID:       trace.SpanID{0, 240, 103, 170, 11, 169, 2, 183},
+				TraceOptions: trace.TraceOptions(1),
+			},
+			wantOk: true,
+		},
+		{
+			name:   "short trace ID",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "short span ID",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b-01",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "non-hex trace ID",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "long options",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0100",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
+		{
+			name:   "non-hex options",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0x",
+			wantSc: trace.SpanContext{},
+			wantOk: false,
+		},
 	}
 
 	f := &HTTPFormat{}
@@ -72,3 +72,48 @@ func TestRoundTripAllFormats(t *testing.T) {
 		srv.Close()
 	}
 }
+
+func TestTracestateRoundTrip(t *testing.T) {
+	const tracestate = "vendor1=value1,vendor2=value2"
+
+	var got string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("tracestate")
+	}))
+	defer backend.Close()
+
+	client := &http.Client{Transport: &Transport{Propagation: &tracecontext.HTTPFormat{}}}
+	frontend := httptest.NewServer(&Handler{
+		Propagation: &tracecontext.HTTPFormat{},
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := trace.StartSpan(r.Context(), "child")
+			defer span.End()
+			req, _ := http.NewRequest("GET", backend.URL, nil)
+			resp, err := client.Do(req.WithContext(ctx))
+			if err != nil {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			resp.Body.Close()
+		}),
+	})
+	defer frontend.Close()
+
+	req, err := http.NewRequest("GET", frontend.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("tracestate", tracestate)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatal(resp.Status)
+	}
+	if got != tracestate {
+		t.Errorf("outbound tracestate = %q; want %q", got, tracestate)
+	}
+}
@@ -19,21 +19,31 @@ import (
 	"net/http"
 
 	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
 // SetRoute sets the http_server_route tag to the given value.
 // It's useful when an HTTP framework does not support the http.Handler interface
 // and using WithRouteTag is not an option, but provides a way to hook into the request flow.
+//
+// Like WithRouteTag, it also names the current span after the route.
 func SetRoute(ctx context.Context, route string) {
 	if a, ok := ctx.Value(addedTagsKey{}).(*addedTags); ok {
 		a.t = append(a.t, tag.Upsert(KeyServerRoute, route))
+		a.routed(route)
 	}
+	setSpanRoute(trace.FromContext(ctx), route)
 }
 
 // WithRouteTag returns an http.Handler that records stats with the
-// http_server_route tag set to the given value.
+// http_server_route tag set to the given value. The span of the request,
+// if any, is named after the route and gets the http.route attribute.
 func WithRouteTag(handler http.Handler, route string) http.Handler {
 	return taggedHandlerFunc(func(w http.ResponseWriter, r *http.Request) []tag.Mutator {
+		setSpanRoute(trace.FromContext(r.Context()), route)
+		if a, ok := r.Context().Value(addedTagsKey{}).(*addedTags); ok {
+			a.routed(route)
+		}
 		addRoute := []tag.Mutator{tag.Upsert(KeyServerRoute, route)}
 		ctx, _ := tag.New(r.Context(), addRoute...)
 		r = r.WithContext(ctx)
@@ -54,8 +64,24 @@ func (h taggedHandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// setSpanRoute names span after route, which has a lower cardinality than
+// the request path used by default.
+func setSpanRoute(span *trace.Span, route string) {
+	span.SetName(route)
+	span.AddAttributes(trace.StringAttribute(RouteAttribute, route))
+}
+
 type addedTagsKey struct{}
 
 type addedTags struct {
 	t []tag.Mutator
+	// setRoute is called with the route of the request once it is known.
+	setRoute func(route string)
+}
+
+// routed reports the route of the request, if a Handler is tracking it.
+func (a *addedTags) routed(route string) {
+	if a.setRoute != nil {
+		a.setRoute(route)
+	}
 }
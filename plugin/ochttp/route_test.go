@@ -23,6 +23,7 @@ import (
 	"go.opencensus.io/plugin/ochttp"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
 func TestWithRouteTag(t *testing.T) {
@@ -64,6 +65,33 @@ func TestWithRouteTag(t *testing.T) {
 	}
 }
 
+func TestWithRouteTagSpanName(t *testing.T) {
+	var e testSpanExporter
+	trace.RegisterExporter(&e)
+	defer trace.UnregisterExporter(&e)
+
+	mux := http.NewServeMux()
+	mux.Handle("/users/", ochttp.WithRouteTag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(204)
+	}), "/users/{id}"))
+	plugin := ochttp.Handler{
+		Handler:      mux,
+		StartOptions: trace.StartOptions{Sampler: trace.AlwaysSample()},
+	}
+	req, _ := http.NewRequest("GET", "/users/12345", nil)
+	plugin.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got, want := len(e.spans), 1; got != want {
+		t.Fatalf("got %d exported spans, want %d", got, want)
+	}
+	if got, want := e.spans[0].Name, "/users/{id}"; got != want {
+		t.Errorf("span name = %q; want %q", got, want)
+	}
+	if got, want := e.spans[0].Attributes[ochttp.RouteAttribute], "/users/{id}"; got != want {
+		t.Errorf("span attribute %s = %v; want %q", ochttp.RouteAttribute, got, want)
+	}
+}
+
 func TestSetRoute(t *testing.T) {
 	v := &view.View{
 		Name:        "request_total",
@@ -104,6 +132,14 @@ func TestSetRoute(t *testing.T) {
 	}
 }
 
+type testSpanExporter struct {
+	spans []*trace.SpanData
+}
+
+func (t *testSpanExporter) ExportSpan(s *trace.SpanData) {
+	t.spans = append(t.spans, s)
+}
+
 type testStatsExporter struct {
 	vd []*view.Data
 }
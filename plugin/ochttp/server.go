@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/stats"
@@ -66,23 +67,55 @@ type Handler struct {
 	// current trace.
 	IsPublicEndpoint bool
 
+	// DebugSamplingHeader, if set, names a request header that forces the
+	// request to be sampled regardless of the configured sampler, for
+	// example to debug a single request. The request is sampled when the
+	// header value parses as true with strconv.ParseBool. Spans started
+	// from the request context inherit the decision.
+	DebugSamplingHeader string
+
+	// SamplingPriority, if true, records the SamplingPriorityAttribute
+	// attribute on the spans of requests force-sampled with
+	// DebugSamplingHeader.
+	SamplingPriority bool
+
+	// MessageEvents, if true, adds a message receive event with the number
+	// of bytes read from the request body by Handler to the span of each
+	// request, including requests without a Content-Length. By default, the
+	// event is only added for requests with a Content-Length, using that
+	// length.
+	MessageEvents bool
+
 	// FormatSpanName holds the function to use for generating the span name
 	// from the information found in the incoming HTTP Request. By default the
 	// name equals the URL Path.
 	FormatSpanName func(*http.Request) string
 
+	// TagPath, if set, transforms the URL path before it is recorded in the
+	// http.path and http.url span attributes and the path stats tag, and
+	// before it is used as the default span name. It can be used to redact identifiers from paths in
+	// order to limit cardinality.
+	TagPath func(path string) string
+
 	// IsHealthEndpoint holds the function to use for determining if the
 	// incoming HTTP request should be considered a health check. This is in
 	// addition to the private isHealthEndpoint func which may also indicate
 	// tracing should be skipped.
 	IsHealthEndpoint func(*http.Request) bool
+
+	// TagPropagationHeader, if set, names the request header from which
+	// tags propagated by a Transport with the same TagPropagationHeader are
+	// read. The propagated tags replace the tags of the request context, so
+	// they are recorded by the server views that have their keys.
+	TagPropagationHeader string
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var tags addedTags
+	r = h.extractTags(r)
 	r, traceEnd := h.startTrace(w, r)
 	defer traceEnd()
-	w, statsEnd := h.startStats(w, r)
+	r, w, statsEnd := h.startStats(w, r, &tags)
 	defer statsEnd(&tags)
 	handler := h.Handler
 	if handler == nil {
@@ -98,7 +131,7 @@ func (h *Handler) startTrace(w http.ResponseWriter, r *http.Request) (*http.Requ
 	}
 	var name string
 	if h.FormatSpanName == nil {
-		name = spanNameFromURL(r)
+		name = formatPath(h.TagPath, spanNameFromURL(r))
 	} else {
 		name = h.FormatSpanName(r)
 	}
@@ -108,6 +141,10 @@ func (h *Handler) startTrace(w http.ResponseWriter, r *http.Request) (*http.Requ
 	if h.GetStartOptions != nil {
 		startOpts = h.GetStartOptions(r)
 	}
+	debug := h.forceSampling(r)
+	if debug {
+		startOpts.Sampler = trace.AlwaysSample()
+	}
 
 	var span *trace.Span
 	sc, ok := h.extractSpanContext(r)
@@ -129,9 +166,22 @@ func (h *Handler) startTrace(w http.ResponseWriter, r *http.Request) (*http.Requ
 			})
 		}
 	}
-	span.AddAttributes(requestAttrs(r)...)
+	span.AddAttributes(requestAttrs(r, formatPath(h.TagPath, r.URL.Path))...)
+	if debug && h.SamplingPriority {
+		span.AddAttributes(trace.StringAttribute(SamplingPriorityAttribute, SamplingPriorityDebug))
+	}
 	if r.Body == nil || r.Body == http.NoBody {
-		// TODO: Handle cases where ContentLength is not set.
+		// No message to record.
+	} else if h.MessageEvents {
+		body := &messageEventBody{body: r.Body, span: span}
+		r = r.WithContext(ctx)
+		r.Body = wrappedBody(body, r.Body)
+		return r, func() {
+			// Record what the handler read, even if it did not read
+			// the body to the end.
+			body.addEvent()
+			span.End()
+		}
 	} else if r.ContentLength > 0 {
 		span.AddMessageReceiveEvent(0, /* TODO: messageID */
 			r.ContentLength, -1)
@@ -139,6 +189,20 @@ func (h *Handler) startTrace(w http.ResponseWriter, r *http.Request) (*http.Requ
 	return r.WithContext(ctx), span.End
 }
 
+// forceSampling reports whether the request carries a true
+// DebugSamplingHeader.
+func (h *Handler) forceSampling(r *http.Request) bool {
+	if h.DebugSamplingHeader == "" {
+		return false
+	}
+	v := r.Header.Get(h.DebugSamplingHeader)
+	if v == "" {
+		return false
+	}
+	force, err := strconv.ParseBool(v)
+	return err == nil && force
+}
+
 func (h *Handler) extractSpanContext(r *http.Request) (trace.SpanContext, bool) {
 	if h.Propagation == nil {
 		return defaultFormat.SpanContextFromRequest(r)
@@ -146,29 +210,68 @@ func (h *Handler) extractSpanContext(r *http.Request) (trace.SpanContext, bool)
 	return h.Propagation.SpanContextFromRequest(r)
 }
 
-func (h *Handler) startStats(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, func(tags *addedTags)) {
+func (h *Handler) startStats(w http.ResponseWriter, r *http.Request, tags *addedTags) (*http.Request, http.ResponseWriter, func(tags *addedTags)) {
 	ctx, _ := tag.New(r.Context(),
 		tag.Upsert(Host, r.Host),
-		tag.Upsert(Path, r.URL.Path),
+		tag.Upsert(Path, formatPath(h.TagPath, r.URL.Path)),
 		tag.Upsert(Method, r.Method))
 	track := &trackingResponseWriter{
 		start:  time.Now(),
 		ctx:    ctx,
+		method: r.Method,
 		writer: w,
 	}
 	if r.Body == nil || r.Body == http.NoBody {
-		// TODO: Handle cases where ContentLength is not set.
 		track.reqSize = -1
-	} else if r.ContentLength > 0 {
-		track.reqSize = r.ContentLength
+	} else {
+		if r.ContentLength > 0 {
+			track.reqSize = r.ContentLength
+		}
+		// Count the bytes actually read by the handler, as ContentLength
+		// is not set for streaming bodies.
+		track.reqBody = &countingBody{body: r.Body}
+		r = r.WithContext(r.Context())
+		r.Body = wrappedBody(track.reqBody, r.Body)
 	}
 	stats.Record(ctx, ServerRequestCount.M(1))
-	return track.wrappedResponseWriter(), track.end
+	addInflight(ctx, r.Method, "", 1)
+	tags.setRoute = track.setRoute
+	return r, track.wrappedResponseWriter(), track.end
+}
+
+// inflightKey identifies the requests counted together in
+// ServerInflightRequests.
+type inflightKey struct {
+	method, route string
+}
+
+// inflight maps each inflightKey to a *int64 holding the number of requests
+// being handled.
+var inflight sync.Map
+
+// addInflight adds delta to the number of requests with the given method
+// and route being handled, and records the new number. The route is empty
+// until the request is routed.
+func addInflight(ctx context.Context, method, route string, delta int64) {
+	key := inflightKey{method, route}
+	n, ok := inflight.Load(key)
+	if !ok {
+		n, _ = inflight.LoadOrStore(key, new(int64))
+	}
+	count := atomic.AddInt64(n.(*int64), delta)
+	var mutators []tag.Mutator
+	if route != "" {
+		mutators = []tag.Mutator{tag.Upsert(KeyServerRoute, route)}
+	}
+	stats.RecordWithTags(ctx, mutators, ServerInflightRequests.M(count))
 }
 
 type trackingResponseWriter struct {
 	ctx        context.Context
 	reqSize    int64
+	reqBody    *countingBody
+	method     string
+	route      string
 	respSize   int64
 	start      time.Time
 	statusCode int
@@ -180,11 +283,23 @@ type trackingResponseWriter struct {
 // Compile time assertion for ResponseWriter interface
 var _ http.ResponseWriter = (*trackingResponseWriter)(nil)
 
+// setRoute counts the request in the in-flight requests of route from now
+// on. It is called by the handler goroutine, before end.
+func (t *trackingResponseWriter) setRoute(route string) {
+	if route == t.route {
+		return
+	}
+	addInflight(t.ctx, t.method, route, 1)
+	addInflight(t.ctx, t.method, t.route, -1)
+	t.route = route
+}
+
 func (t *trackingResponseWriter) end(tags *addedTags) {
 	t.endOnce.Do(func() {
 		if t.statusCode == 0 {
 			t.statusCode = 200
 		}
+		addInflight(t.ctx, t.method, t.route, -1)
 
 		span := trace.FromContext(t.ctx)
 		span.SetStatus(TraceStatus(t.statusCode, t.statusLine))
@@ -194,8 +309,8 @@ func (t *trackingResponseWriter) end(tags *addedTags) {
 			ServerLatency.M(float64(time.Since(t.start)) / float64(time.Millisecond)),
 			ServerResponseBytes.M(t.respSize),
 		}
-		if t.reqSize >= 0 {
-			m = append(m, ServerRequestBytes.M(t.reqSize))
+		if reqSize := t.reqBody.size(t.reqSize); reqSize >= 0 {
+			m = append(m, ServerRequestBytes.M(reqSize))
 		}
 		allTags := make([]tag.Mutator, len(tags.t)+1)
 		allTags[0] = tag.Upsert(StatusCode, strconv.Itoa(t.statusCode))
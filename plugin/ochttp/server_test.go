@@ -616,3 +616,150 @@ func TestIgnoreHealthEndpoints(t *testing.T) {
 		t.Errorf("Got %v spans; want no spans", spans)
 	}
 }
+
+func TestHandlerDebugSamplingHeader(t *testing.T) {
+	tests := []struct {
+		header    string
+		wantSpans int
+	}{
+		{"", 0},
+		{"false", 0},
+		{"garbage", 0},
+		{"true", 2},
+		{"1", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			var spans collector
+			trace.RegisterExporter(&spans)
+			defer trace.UnregisterExporter(&spans)
+
+			h := &Handler{
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, child := trace.StartSpan(r.Context(), "child")
+					child.End()
+				}),
+				StartOptions:        trace.StartOptions{Sampler: trace.NeverSample()},
+				DebugSamplingHeader: "X-Debug-Trace",
+			}
+			req := httptest.NewRequest("GET", "http://example.com/", nil)
+			if tt.header != "" {
+				req.Header.Set("X-Debug-Trace", tt.header)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got := len(spans); got != tt.wantSpans {
+				t.Errorf("got %d exported spans; want %d", got, tt.wantSpans)
+			}
+		})
+	}
+}
+
+func TestHandlerSamplingPriority(t *testing.T) {
+	for _, debug := range []bool{false, true} {
+		var spans collector
+		trace.RegisterExporter(&spans)
+
+		h := &Handler{
+			Handler:             http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+			StartOptions:        trace.StartOptions{Sampler: trace.AlwaysSample()},
+			DebugSamplingHeader: "X-Debug-Trace",
+			SamplingPriority:    true,
+		}
+		req := httptest.NewRequest("GET", "http://example.com/", nil)
+		if debug {
+			req.Header.Set("X-Debug-Trace", "true")
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		trace.UnregisterExporter(&spans)
+
+		if len(spans) != 1 {
+			t.Fatalf("got %d exported spans; want 1", len(spans))
+		}
+		got, ok := spans[0].Attributes[SamplingPriorityAttribute]
+		if ok != debug || debug && got != SamplingPriorityDebug {
+			t.Errorf("debug=%t: %s attribute = %v (present: %t)", debug, SamplingPriorityAttribute, got, ok)
+		}
+	}
+}
+
+func TestHandlerInflightRequests(t *testing.T) {
+	if err := view.Register(ServerInflightView); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(ServerInflightView)
+
+	// inflightFor returns the in-flight requests with the given method and
+	// route, or -1 if there is no such row.
+	inflightFor := func(method, route string) int64 {
+		rows, err := view.RetrieveData(ServerInflightView.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range rows {
+			var m, r string
+			for _, tag := range row.Tags {
+				switch tag.Key {
+				case Method:
+					m = tag.Value
+				case KeyServerRoute:
+					r = tag.Value
+				}
+			}
+			if m == method && r == route {
+				return int64(row.Data.(*view.LastValueData).Value)
+			}
+		}
+		return -1
+	}
+
+	const n = 5
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		if r.URL.Path == "/panic" {
+			panic(http.ErrAbortHandler)
+		}
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/", handler)
+	mux.Handle("/users/", WithRouteTag(handler, "/users/"))
+	h := &Handler{Handler: mux}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		path := "/users/1"
+		switch i {
+		case 0:
+			path = "/panic"
+		case 1:
+			path = "/"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { recover() }()
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+		}()
+	}
+	for i := 0; i < n; i++ {
+		<-started
+	}
+	if got := inflightFor("GET", ""); got != 2 {
+		t.Errorf("in-flight unrouted GET requests = %d; want 2", got)
+	}
+	if got := inflightFor("GET", "/users/"); got != n-2 {
+		t.Errorf("in-flight GET /users/ requests = %d; want %d", got, n-2)
+	}
+
+	close(release)
+	wg.Wait()
+	if got := inflightFor("GET", ""); got != 0 {
+		t.Errorf("in-flight unrouted GET requests after completion = %d; want 0", got)
+	}
+	if got := inflightFor("GET", "/users/"); got != 0 {
+		t.Errorf("in-flight GET /users/ requests after completion = %d; want 0", got)
+	}
+}
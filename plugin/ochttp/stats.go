@@ -81,11 +81,19 @@ var (
 		"opencensus.io/http/server/latency",
 		"End-to-end latency",
 		stats.UnitMilliseconds)
+	// ServerInflightRequests is the number of requests being handled, per
+	// HTTP method and route, recorded whenever a request starts, is routed
+	// or ends.
+	ServerInflightRequests = stats.Int64(
+		"opencensus.io/http/server/inflight_requests",
+		"Number of HTTP requests being handled",
+		stats.UnitDimensionless)
 )
 
 // The following tags are applied to stats recorded by this package. Host, Path
 // and Method are applied to all measures. StatusCode is not applied to
-// ClientRequestCount or ServerRequestCount, since it is recorded before the status is known.
+// ClientRequestCount, ServerRequestCount or ServerInflightRequests, since they
+// are recorded before the status is known.
 var (
 	// Host is the value of the HTTP Host header.
 	//
@@ -128,7 +136,7 @@ var (
 
 // Default distributions used by views in this package.
 var (
-	DefaultSizeDistribution    = view.Distribution(1024, 2048, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864, 268435456, 1073741824, 4294967296)
+	DefaultSizeDistribution    = view.DefaultSizeDistribution()
 	DefaultLatencyDistribution = view.Distribution(1, 2, 3, 4, 5, 6, 8, 10, 13, 16, 20, 25, 30, 40, 50, 65, 80, 100, 130, 160, 200, 250, 300, 400, 500, 650, 800, 1000, 2000, 5000, 10000, 20000, 50000, 100000)
 )
 
@@ -267,6 +275,17 @@ var (
 		Measure:     ServerLatency,
 		Aggregation: view.Count(),
 	}
+
+	// ServerInflightView is a gauge of the requests being handled by
+	// method and route. Requests are counted without a route until
+	// WithRouteTag or SetRoute sets it.
+	ServerInflightView = &view.View{
+		Name:        "opencensus.io/http/server/inflight_requests",
+		Description: "Number of HTTP requests being handled, by HTTP method and route",
+		TagKeys:     []tag.Key{Method, KeyServerRoute},
+		Measure:     ServerInflightRequests,
+		Aggregation: view.LastValue(),
+	}
 )
 
 // DefaultClientViews are the default client views provided by this package.
@@ -15,6 +15,10 @@
 package ochttp
 
 import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
@@ -86,3 +90,63 @@ func TestClientMeasures(t *testing.T) {
 		}
 	}
 }
+
+func TestStreamingBodySizes(t *testing.T) {
+	const reqSize, respSize = 3000, 5000
+	measures := []stats.Measure{ClientSentBytes, ClientReceivedBytes, ServerRequestBytes, ServerResponseBytes}
+	views := make([]*view.View, len(measures))
+	for i, m := range measures {
+		views[i] = &view.View{
+			Name:        "TestStreamingBodySizes/" + m.Name(),
+			Measure:     m,
+			Aggregation: view.Sum(),
+		}
+	}
+	if err := view.Register(views...); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(views...)
+
+	server := httptest.NewServer(&Handler{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(ioutil.Discard, r.Body)
+			w.(http.Flusher).Flush()
+			w.Write(make([]byte, respSize))
+		}),
+	})
+	defer server.Close()
+
+	// Hide the length of the body so that it is sent chunked.
+	body := struct{ io.Reader }{strings.NewReader(strings.Repeat("a", reqSize))}
+	client := &http.Client{Transport: &Transport{}}
+	resp, err := client.Post(server.URL, "text/plain", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.Copy(ioutil.Discard, resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	// The server records its measurements after the response is written.
+	server.Close()
+
+	want := map[string]float64{
+		ClientSentBytes.Name():     reqSize,
+		ClientReceivedBytes.Name(): respSize,
+		ServerRequestBytes.Name():  reqSize,
+		ServerResponseBytes.Name(): respSize,
+	}
+	for _, v := range views {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rows) != 1 {
+			t.Errorf("%s: got %d rows; want 1", v.Measure.Name(), len(rows))
+			continue
+		}
+		if got := rows[0].Data.(*view.SumData).Value; got != want[v.Measure.Name()] {
+			t.Errorf("%s = %v; want %v", v.Measure.Name(), got, want[v.Measure.Name()])
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ochttp
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"go.opencensus.io/tag"
+)
+
+// tagTransport is an http.RoundTripper that propagates the tags of the
+// request context in a header.
+type tagTransport struct {
+	base   http.RoundTripper
+	header string
+}
+
+func (t *tagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if m := tag.FromContext(req.Context()); m != nil {
+		// Copy the request, as a RoundTripper must not modify it.
+		req = req.WithContext(req.Context())
+		header := make(http.Header)
+		for k, v := range req.Header {
+			header[k] = v
+		}
+		req.Header = header
+		req.Header.Set(t.header, base64.StdEncoding.EncodeToString(tag.Encode(m)))
+	}
+	return t.base.RoundTrip(req)
+}
+
+// extractTags returns r with the tags propagated in the TagPropagationHeader
+// header, if any, installed in its context. Malformed headers are ignored.
+func (h *Handler) extractTags(r *http.Request) *http.Request {
+	if h.TagPropagationHeader == "" {
+		return r
+	}
+	v := r.Header.Get(h.TagPropagationHeader)
+	if v == "" {
+		return r
+	}
+	b, err := base64.StdEncoding.DecodeString(v)
+	if err != nil {
+		return r
+	}
+	m, err := tag.Decode(b)
+	if err != nil {
+		return r
+	}
+	return r.WithContext(tag.NewContext(r.Context(), m))
+}
@@ -0,0 +1,75 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ochttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestTagPropagation(t *testing.T) {
+	const header = "X-Census-Tags"
+	customer := tag.MustNewKey("customer")
+	v := &view.View{
+		Name:        "TestTagPropagation/request_count",
+		Measure:     ServerRequestCount,
+		TagKeys:     []tag.Key{customer},
+		Aggregation: view.Count(),
+	}
+	if err := view.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(v)
+
+	var gotHeader string
+	srv := httptest.NewServer(&Handler{
+		TagPropagationHeader: header,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotHeader = r.Header.Get(header)
+		}),
+	})
+	defer srv.Close()
+
+	client := &http.Client{Transport: &Transport{TagPropagationHeader: header}}
+	ctx, _ := tag.New(context.Background(), tag.Upsert(customer, "acme"))
+	req, _ := http.NewRequest("GET", srv.URL, nil)
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if gotHeader == "" {
+		t.Fatalf("%s header was not sent", header)
+	}
+	if req.Header.Get(header) != "" {
+		t.Errorf("Transport modified the header of the request")
+	}
+
+	rows, err := view.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1: %v", len(rows), rows)
+	}
+	if got, want := rows[0].Tags, []tag.Tag{{Key: customer, Value: "acme"}}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("row tags = %v; want %v", got, want)
+	}
+}
@@ -34,21 +34,32 @@ const (
 	HostAttribute       = "http.host"
 	MethodAttribute     = "http.method"
 	PathAttribute       = "http.path"
+	RouteAttribute      = "http.route"
 	URLAttribute        = "http.url"
 	UserAgentAttribute  = "http.user_agent"
 	StatusCodeAttribute = "http.status_code"
 )
 
+// SamplingPriorityAttribute is the attribute recorded on the server span of
+// requests force-sampled with Handler.DebugSamplingHeader when
+// Handler.SamplingPriority is set. Its value is SamplingPriorityDebug, which
+// tells downstream systems the trace is a debug trace rather than a
+// probabilistic sample.
+const (
+	SamplingPriorityAttribute = "sampling.priority"
+	SamplingPriorityDebug     = "debug"
+)
+
 type traceTransport struct {
 	base           http.RoundTripper
 	startOptions   trace.StartOptions
 	format         propagation.HTTPFormat
 	formatSpanName func(*http.Request) string
 	newClientTrace func(*http.Request, *trace.Span) *httptrace.ClientTrace
+	tagPath        func(string) string
+	messageEvents  bool
 }
 
-// TODO(jbd): Add message events for request and response size.
-
 // RoundTrip creates a trace.Span and inserts it into the outgoing request's headers.
 // The created span can follow a parent span, if a parent is presented in
 // the request's context.
@@ -80,7 +91,10 @@ func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		t.format.SpanContextToRequest(span.SpanContext(), req)
 	}
 
-	span.AddAttributes(requestAttrs(req)...)
+	span.AddAttributes(requestAttrs(req, formatPath(t.tagPath, req.URL.Path))...)
+	if t.messageEvents && req.Body != nil && req.Body != http.NoBody {
+		req.Body = wrappedBody(&messageEventBody{body: req.Body, span: span, send: true}, req.Body)
+	}
 	resp, err := t.base.RoundTrip(req)
 	if err != nil {
 		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
@@ -94,6 +108,9 @@ func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// span.End() will be invoked after
 	// a read from resp.Body returns io.EOF or when
 	// resp.Body.Close() is invoked.
+	if t.messageEvents {
+		resp.Body = wrappedBody(&messageEventBody{body: resp.Body, span: span}, resp.Body)
+	}
 	bt := &bodyTracker{rc: resp.Body, span: span}
 	resp.Body = wrappedBody(bt, resp.Body)
 	return resp, err
@@ -132,8 +149,9 @@ func (bt *bodyTracker) Close() error {
 	// Invoking endSpan on Close will help catch the cases
 	// in which a read returned a non-nil error, we set the
 	// span status but didn't end the span.
+	err := bt.rc.Close()
 	bt.span.End()
-	return bt.rc.Close()
+	return err
 }
 
 // CancelRequest cancels an in-flight request by closing its connection.
@@ -150,13 +168,30 @@ func spanNameFromURL(req *http.Request) string {
 	return req.URL.Path
 }
 
-func requestAttrs(r *http.Request) []trace.Attribute {
+// formatPath returns path transformed by f, or path itself if f is nil.
+func formatPath(f func(string) string, path string) string {
+	if f == nil {
+		return path
+	}
+	return f(path)
+}
+
+// requestAttrs returns the attributes of r, with path, as transformed by
+// TagPath, in place of the path of its URL.
+func requestAttrs(r *http.Request, path string) []trace.Attribute {
 	userAgent := r.UserAgent()
 
+	u := r.URL
+	if path != u.Path {
+		copied := *u
+		copied.Path, copied.RawPath = path, ""
+		u = &copied
+	}
+
 	attrs := make([]trace.Attribute, 0, 5)
 	attrs = append(attrs,
-		trace.StringAttribute(PathAttribute, r.URL.Path),
-		trace.StringAttribute(URLAttribute, r.URL.String()),
+		trace.StringAttribute(PathAttribute, path),
+		trace.StringAttribute(URLAttribute, u.String()),
 		trace.StringAttribute(HostAttribute, r.Host),
 		trace.StringAttribute(MethodAttribute, r.Method),
 	)
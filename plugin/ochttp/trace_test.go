@@ -29,12 +29,15 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"go.opencensus.io/plugin/ochttp/propagation/b3"
 	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 )
 
@@ -151,6 +154,53 @@ func TestTransport_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestTransport_GetStartOptions(t *testing.T) {
+	ctx, parent := trace.StartSpan(context.Background(), "parent", trace.WithSampler(trace.NeverSample()))
+	defer parent.End()
+
+	tests := []struct {
+		name            string
+		getStartOptions func(*http.Request) trace.StartOptions
+		wantSpans       int
+	}{
+		{
+			name:      "static options",
+			wantSpans: 1,
+		},
+		{
+			name: "per-request options take precedence",
+			getStartOptions: func(r *http.Request) trace.StartOptions {
+				if !trace.FromContext(r.Context()).SpanContext().IsSampled() {
+					return trace.StartOptions{Sampler: trace.NeverSample()}
+				}
+				return trace.StartOptions{}
+			},
+			wantSpans: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := &testExporter{}
+			trace.RegisterExporter(exporter)
+			defer trace.UnregisterExporter(exporter)
+
+			transport := &testTransport{ch: make(chan *http.Request, 1)}
+			rt := &Transport{
+				Base:            transport,
+				StartOptions:    trace.StartOptions{Sampler: trace.AlwaysSample()},
+				GetStartOptions: tt.getStartOptions,
+			}
+			req, _ := http.NewRequest("GET", "http://foo.com", nil)
+			rt.RoundTrip(req.WithContext(ctx))
+			<-transport.ch
+
+			if got := len(exporter.spans); got != tt.wantSpans {
+				t.Errorf("got %d exported spans; want %d", got, tt.wantSpans)
+			}
+		})
+	}
+}
+
 func TestHandler(t *testing.T) {
 	traceID := [16]byte{16, 84, 69, 170, 120, 67, 188, 139, 242, 6, 177, 32, 0, 16, 0, 0}
 	tests := []struct {
@@ -455,6 +505,172 @@ func TestFormatSpanName(t *testing.T) {
 	}
 }
 
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestMessageEvents(t *testing.T) {
+	const reqBody, respBody = "hello server", "hello client, and more"
+	wantEvents := func(t *testing.T, span *trace.SpanData, want map[trace.MessageEventType]int64) {
+		t.Helper()
+		got := make(map[trace.MessageEventType]int64)
+		for _, e := range span.MessageEvents {
+			got[e.EventType] += e.UncompressedByteSize
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s span message event sizes = %v; want %v", span.Name, got, want)
+		}
+	}
+
+	t.Run("server", func(t *testing.T) {
+		var spans collector
+		trace.RegisterExporter(&spans)
+		defer trace.UnregisterExporter(&spans)
+
+		h := &Handler{
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ioutil.ReadAll(r.Body)
+			}),
+			StartOptions:  trace.StartOptions{Sampler: trace.AlwaysSample()},
+			MessageEvents: true,
+		}
+		// A reader of unknown length, so the request has no Content-Length.
+		req := httptest.NewRequest("POST", "http://example.com/", io.MultiReader(strings.NewReader(reqBody)))
+		if req.ContentLength > 0 {
+			t.Fatalf("request has a Content-Length")
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		if len(spans) != 1 {
+			t.Fatalf("got %d spans; want 1", len(spans))
+		}
+		wantEvents(t, spans[0], map[trace.MessageEventType]int64{trace.MessageEventTypeRecv: int64(len(reqBody))})
+	})
+
+	t.Run("client", func(t *testing.T) {
+		var spans collector
+		trace.RegisterExporter(&spans)
+		defer trace.UnregisterExporter(&spans)
+
+		base := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ioutil.ReadAll(req.Body)
+			req.Body.Close()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(respBody)),
+			}, nil
+		})
+		client := &http.Client{Transport: &Transport{
+			Base:          base,
+			StartOptions:  trace.StartOptions{Sampler: trace.AlwaysSample()},
+			MessageEvents: true,
+		}}
+		res, err := client.Post("http://example.com/", "text/plain", strings.NewReader(reqBody))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if len(spans) != 1 {
+			t.Fatalf("got %d spans; want 1", len(spans))
+		}
+		wantEvents(t, spans[0], map[trace.MessageEventType]int64{
+			trace.MessageEventTypeSent: int64(len(reqBody)),
+			trace.MessageEventTypeRecv: int64(len(respBody)),
+		})
+	})
+}
+
+func TestTagPath(t *testing.T) {
+	digits := regexp.MustCompile(`[0-9]+`)
+	tagPath := func(path string) string {
+		return digits.ReplaceAllString(path, "{id}")
+	}
+
+	serverView := &view.View{
+		Name:        "test_tag_path_server",
+		Measure:     ServerRequestCount,
+		TagKeys:     []tag.Key{Path},
+		Aggregation: view.Count(),
+	}
+	clientView := &view.View{
+		Name:        "test_tag_path_client",
+		Measure:     ClientRequestCount,
+		TagKeys:     []tag.Key{KeyClientPath},
+		Aggregation: view.Count(),
+	}
+	if err := view.Register(serverView, clientView); err != nil {
+		t.Fatalf("view.Register() = %v", err)
+	}
+	defer view.Unregister(serverView, clientView)
+
+	handler := &Handler{
+		Handler: http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			resp.Write([]byte("Hello, world!"))
+		}),
+		StartOptions: trace.StartOptions{
+			Sampler: trace.AlwaysSample(),
+		},
+		TagPath: tagPath,
+	}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			StartOptions: trace.StartOptions{
+				Sampler: trace.AlwaysSample(),
+			},
+			TagPath: tagPath,
+		},
+	}
+
+	var te testExporter
+	trace.RegisterExporter(&te)
+	res, err := client.Get(server.URL + "/users/12345/orders/98765")
+	if err != nil {
+		t.Fatalf("error creating request: %v", err)
+	}
+	res.Body.Close()
+	trace.UnregisterExporter(&te)
+
+	const want = "/users/{id}/orders/{id}"
+	if got, want := len(te.spans), 2; got != want {
+		t.Fatalf("got %d exported spans, want %d", got, want)
+	}
+	for _, s := range te.spans {
+		if s.Name != want {
+			t.Errorf("span name = %q; want %q", s.Name, want)
+		}
+		if got := s.Attributes[PathAttribute]; got != want {
+			t.Errorf("span attribute %s = %v; want %q", PathAttribute, got, want)
+		}
+		wantURL := "/users/%7Bid%7D/orders/%7Bid%7D"
+		if s.SpanKind == trace.SpanKindClient {
+			wantURL = server.URL + wantURL
+		}
+		if got := s.Attributes[URLAttribute]; got != wantURL {
+			t.Errorf("span attribute %s = %v; want %q", URLAttribute, got, wantURL)
+		}
+	}
+
+	for _, v := range []*view.View{serverView, clientView} {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			t.Fatalf("view.RetrieveData(%q) = %v", v.Name, err)
+		}
+		if len(rows) != 1 || len(rows[0].Tags) != 1 {
+			t.Fatalf("view %q: got rows %v, want one row with one tag", v.Name, rows)
+		}
+		if got := rows[0].Tags[0].Value; got != want {
+			t.Errorf("view %q: path tag = %q; want %q", v.Name, got, want)
+		}
+	}
+}
+
 func TestRequestAttributes(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -481,7 +697,7 @@ func TestRequestAttributes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			req := tt.makeReq()
-			attrs := requestAttrs(req)
+			attrs := requestAttrs(req, req.URL.Path)
 
 			if got, want := attrs, tt.wantAttrs; !reflect.DeepEqual(got, want) {
 				t.Errorf("Request attributes = %#v; want %#v", got, want)
@@ -16,6 +16,10 @@ package ochttp
 
 import (
 	"io"
+	"sync"
+	"sync/atomic"
+
+	"go.opencensus.io/trace"
 )
 
 // wrappedBody returns a wrapped version of the original
@@ -42,3 +46,74 @@ func wrappedBody(wrapper io.ReadCloser, body io.ReadCloser) io.ReadCloser {
 		}{wrapper}
 	}
 }
+
+// countingBody counts the bytes read from a request body, which may be
+// read by the transport concurrently with the caller.
+type countingBody struct {
+	body io.ReadCloser
+	n    int64 // accessed atomically
+}
+
+var _ io.ReadCloser = (*countingBody)(nil)
+
+func (c *countingBody) Read(b []byte) (int, error) {
+	n, err := c.body.Read(b)
+	atomic.AddInt64(&c.n, int64(n))
+	return n, err
+}
+
+func (c *countingBody) Close() error {
+	return c.body.Close()
+}
+
+// size returns the number of bytes read so far, or contentLength if nothing
+// was read.
+func (c *countingBody) size(contentLength int64) int64 {
+	if c == nil {
+		return contentLength
+	}
+	if n := atomic.LoadInt64(&c.n); n > 0 {
+		return n
+	}
+	return contentLength
+}
+
+// messageEventBody adds a message event with the number of bytes read from a
+// body to a span, once the body is read to the end or closed.
+type messageEventBody struct {
+	body io.ReadCloser
+	span *trace.Span
+	send bool  // whether to add a send event rather than a receive event
+	n    int64 // accessed atomically
+	once sync.Once
+}
+
+var _ io.ReadCloser = (*messageEventBody)(nil)
+
+func (b *messageEventBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	atomic.AddInt64(&b.n, int64(n))
+	if err == io.EOF {
+		b.addEvent()
+	}
+	return n, err
+}
+
+func (b *messageEventBody) Close() error {
+	b.addEvent()
+	return b.body.Close()
+}
+
+func (b *messageEventBody) addEvent() {
+	b.once.Do(func() {
+		n := atomic.LoadInt64(&b.n)
+		if n == 0 {
+			return
+		}
+		if b.send {
+			b.span.AddMessageSendEvent(0 /* TODO: messageID */, n, -1)
+		} else {
+			b.span.AddMessageReceiveEvent(0 /* TODO: messageID */, n, -1)
+		}
+	})
+}
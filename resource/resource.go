@@ -24,6 +24,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"go.opencensus.io/resource/resourcekeys"
 )
 
 // Environment variables used by FromEnv to decode a resource.
@@ -108,6 +110,21 @@ func FromEnv(context.Context) (*Resource, error) {
 
 var _ Detector = FromEnv
 
+// FromHost is a detector that returns a host resource labeled with the host
+// name reported by the kernel.
+func FromHost(context.Context) (*Resource, error) {
+	name, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	return &Resource{
+		Type:   resourcekeys.HostType,
+		Labels: map[string]string{resourcekeys.HostKeyHostName: name},
+	}, nil
+}
+
+var _ Detector = FromHost
+
 // merge resource information from b into a. In case of a collision, a takes precedence.
 func merge(a, b *Resource) *Resource {
 	if a == nil {
@@ -162,3 +179,20 @@ func detectAll(ctx context.Context, detectors ...Detector) (*Resource, error) {
 	}
 	return res, nil
 }
+
+// Detect calls all input detectors in order and merges their results.
+// Unlike MultiDetector, a later detector takes precedence over the earlier
+// ones when they set the type or the same label key, so general detectors
+// can be listed first and more specific ones after.
+// It returns on the first error that a detector encounters.
+func Detect(ctx context.Context, detectors ...Detector) (*Resource, error) {
+	var res *Resource
+	for _, d := range detectors {
+		r, err := d(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res = merge(r, res)
+	}
+	return res, nil
+}
@@ -18,8 +18,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"reflect"
 	"testing"
+
+	"go.opencensus.io/resource/resourcekeys"
 )
 
 func TestMerge(t *testing.T) {
@@ -161,3 +164,58 @@ func TestMultiDetector(t *testing.T) {
 		t.Fatalf("unexpected error: want %v, got %v", wantErr, err)
 	}
 }
+
+func TestDetect(t *testing.T) {
+	got, err := Detect(context.Background(),
+		func(context.Context) (*Resource, error) {
+			return &Resource{
+				Type:   "t1",
+				Labels: map[string]string{"a": "1", "b": "2"},
+			}, nil
+		},
+		func(context.Context) (*Resource, error) {
+			return nil, nil
+		},
+		func(context.Context) (*Resource, error) {
+			return &Resource{
+				Type:   "t2",
+				Labels: map[string]string{"a": "11", "c": "3"},
+			}, nil
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := &Resource{
+		Type:   "t2",
+		Labels: map[string]string{"a": "11", "b": "2", "c": "3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected resource: want %v, got %v", want, got)
+	}
+}
+
+func TestDetectFromEnv(t *testing.T) {
+	defer os.Setenv(EnvVarType, os.Getenv(EnvVarType))
+	defer os.Setenv(EnvVarLabels, os.Getenv(EnvVarLabels))
+
+	os.Setenv(EnvVarType, "t1")
+	os.Setenv(EnvVarLabels, `a="1",b="2"`)
+	got, err := Detect(context.Background(), FromHost, FromEnv)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	host, _ := os.Hostname()
+	want := &Resource{
+		Type:   "t1",
+		Labels: map[string]string{"a": "1", "b": "2", resourcekeys.HostKeyHostName: host},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected resource: want %v, got %v", want, got)
+	}
+
+	os.Setenv(EnvVarLabels, `a=1,b`)
+	if _, err := Detect(context.Background(), FromHost, FromEnv); err == nil {
+		t.Fatal("expected error for malformed labels")
+	}
+}
@@ -107,6 +107,33 @@ func BenchmarkRecord8_8Tags(b *testing.B) {
 	}
 }
 
+func BenchmarkRecord1_TagNew(b *testing.B) {
+	ctx := context.Background()
+	key := tag.MustNewKey("key")
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ctx, err := tag.New(ctx, tag.Upsert(key, "value"))
+		if err != nil {
+			b.Fatal(err)
+		}
+		stats.Record(ctx, m.M(1))
+	}
+}
+
+func BenchmarkRecord1_WithTags(b *testing.B) {
+	ctx := context.Background()
+	key := tag.MustNewKey("key")
+	mutators := []tag.Mutator{tag.Upsert(key, "value")}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := stats.RecordWithTags(ctx, mutators, m.M(1)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func makeMeasure() *stats.Int64Measure {
 	m := stats.Int64("m", "test measure", "")
 	v := &view.View{
@@ -118,3 +145,27 @@ func makeMeasure() *stats.Int64Measure {
 	}
 	return m
 }
+
+func BenchmarkRecord100_Unbuffered(b *testing.B) {
+	ctx := context.Background()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 100; j++ {
+			stats.Record(ctx, m.M(1))
+		}
+	}
+}
+
+func BenchmarkRecord100_Buffered(b *testing.B) {
+	ctx := context.Background()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		r := stats.NewRecorder(ctx)
+		for j := 0; j < 100; j++ {
+			r.Record(m.M(1))
+		}
+		r.Flush()
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package stats
+
+import "context"
+
+// BufferedRecorder buffers measurements and records them all at once on
+// Flush, with the tags of its context. Recording many measurements per
+// request through a BufferedRecorder hands them to the view worker in a
+// single round trip instead of one per call to Record.
+//
+// Buffered measurements are aggregated when flushed, so they are not
+// visible in views until then. A BufferedRecorder is not safe for
+// concurrent use; use one per request or goroutine.
+type BufferedRecorder struct {
+	ctx context.Context
+	ms  []Measurement
+}
+
+// NewRecorder returns a BufferedRecorder recording measurements with the
+// tags and the span of ctx.
+func NewRecorder(ctx context.Context) *BufferedRecorder {
+	return &BufferedRecorder{ctx: ctx}
+}
+
+// Record adds ms to the measurements to record on Flush.
+func (r *BufferedRecorder) Record(ms ...Measurement) {
+	r.ms = append(r.ms, ms...)
+}
+
+// Flush records the buffered measurements as Record does, and empties the
+// buffer.
+func (r *BufferedRecorder) Flush() {
+	// The view worker keeps the slice, so a new one is started.
+	ms := r.ms
+	r.ms = nil
+	Record(r.ctx, ms...)
+}
@@ -58,9 +58,10 @@ When aggregated using a Distribution aggregation, an exemplar is kept for each
 bucket in the Distribution. This allows you to easily find an example of a
 measurement that fell into each bucket.
 
-For example, if you also use the OpenCensus trace package and you
-record a measurement with a context that contains a sampled trace span,
-then the trace span will be added to the exemplar associated with the measurement.
+For example, if you also use the OpenCensus trace package, enable
+SetSpanContextExemplars and record a measurement with a context that contains
+a sampled trace span, then the trace span will be added to the exemplar
+associated with the measurement.
 
 When exported to a supporting back end, you should be able to easily navigate
 to example traces that fell into each bucket in the Distribution.
@@ -15,12 +15,24 @@
 package internal
 
 import (
+	"time"
+
 	"go.opencensus.io/tag"
 )
 
 // DefaultRecorder will be called for each Record call.
 var DefaultRecorder func(tags *tag.Map, measurement interface{}, attachments map[string]interface{})
 
+// DefaultTimedRecorder is like DefaultRecorder, but records the measurements
+// at the given time rather than the current time.
+var DefaultTimedRecorder func(tags *tag.Map, measurement interface{}, attachments map[string]interface{}, t time.Time)
+
+// TimedRecorder is implemented by recorders that can record measurements at
+// a given time.
+type TimedRecorder interface {
+	RecordAt(tags *tag.Map, measurement interface{}, attachments map[string]interface{}, t time.Time)
+}
+
 // MeasurementRecorder will be called for each Record call. This is the same as DefaultRecorder but
 // avoids interface{} conversion.
 // This will be a func(tags *tag.Map, measurement []Measurement, attachments map[string]interface{}) type,
@@ -29,3 +41,8 @@ var MeasurementRecorder interface{}
 
 // SubscriptionReporter reports when a view subscribed with a measure.
 var SubscriptionReporter func(measure string)
+
+// OnRecordDropped returns the function to call with the measure of each
+// recorded measurement that matches no view, or nil if there is none. The
+// measure is a stats.Measure, but is interface{} here to avoid import loops.
+var OnRecordDropped func() func(measure interface{})
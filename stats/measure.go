@@ -16,6 +16,8 @@
 package stats
 
 import (
+	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 )
@@ -48,6 +50,37 @@ type Measure interface {
 	Unit() string
 }
 
+// maxMeasureNameLength is the max length of measure names accepted by
+// ValidateMeasureName, the same as for tag keys.
+const maxMeasureNameLength = 255
+
+// ValidateMeasureName returns an error describing why name is not a portable
+// measure name, or nil if it is. Names must be non-empty, at most 255
+// characters long and only hold ASCII letters, digits, '_', '.', '/' and
+// '-', which is what the strictest exporters accept; spaces are not
+// allowed. For example, "example.com/request_latency" is valid.
+//
+// Float64 and Int64 accept any name; MustFloat64 and MustInt64 validate it.
+func ValidateMeasureName(name string) error {
+	if name == "" {
+		return fmt.Errorf("invalid measure name: name is empty")
+	}
+	if len(name) > maxMeasureNameLength {
+		return fmt.Errorf("invalid measure name %q: longer than %d characters", name, maxMeasureNameLength)
+	}
+	for _, c := range name {
+		if !isMeasureNameChar(c) {
+			return fmt.Errorf("invalid measure name %q: character %q is not allowed; use ASCII letters, digits, '_', '.', '/' or '-'", name, c)
+		}
+	}
+	return nil
+}
+
+func isMeasureNameChar(c rune) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '_' || c == '.' || c == '/' || c == '-'
+}
+
 // measureDescriptor is the untyped descriptor associated with each measure.
 // Int64Measure and Float64Measure wrap measureDescriptor to provide typed
 // recording APIs.
@@ -58,6 +91,9 @@ type measureDescriptor struct {
 	name        string
 	description string
 	unit        string
+
+	// measure is the measure the descriptor was first registered for.
+	measure Measure
 }
 
 func (m *measureDescriptor) subscribe() {
@@ -73,7 +109,7 @@ var (
 	measures = make(map[string]*measureDescriptor)
 )
 
-func registerMeasureHandle(name, desc, unit string) *measureDescriptor {
+func registerMeasureHandle(name, desc, unit string, newMeasure func(*measureDescriptor) Measure) *measureDescriptor {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -85,15 +121,30 @@ func registerMeasureHandle(name, desc, unit string) *measureDescriptor {
 		description: desc,
 		unit:        unit,
 	}
+	m.measure = newMeasure(m)
 	measures[name] = m
 	return m
 }
 
+// RegisteredMeasures returns the measures created so far, sorted by name.
+// Measures created again with the same name are only listed once.
+func RegisteredMeasures() []Measure {
+	mu.RLock()
+	defer mu.RUnlock()
+	ms := make([]Measure, 0, len(measures))
+	for _, m := range measures {
+		ms = append(ms, m.measure)
+	}
+	sort.Slice(ms, func(i, j int) bool { return ms[i].Name() < ms[j].Name() })
+	return ms
+}
+
 // Measurement is the numeric value measured when recording stats. Each measure
 // provides methods to create measurements of their kind. For example, Int64Measure
 // provides M to convert an int64 into a measurement.
 type Measurement struct {
 	v    float64
+	i    int64 // exact value of measurements of an Int64Measure
 	m    Measure
 	desc *measureDescriptor
 }
@@ -103,6 +154,14 @@ func (m Measurement) Value() float64 {
 	return m.v
 }
 
+// Int64Value returns the exact value of the Measurement and true if it was
+// created by an Int64Measure, and false otherwise. Value may have lost
+// precision for values beyond 2^53.
+func (m Measurement) Int64Value() (int64, bool) {
+	_, ok := m.m.(*Int64Measure)
+	return m.i, ok
+}
+
 // Measure returns the Measure from which this Measurement was created.
 func (m Measurement) Measure() Measure {
 	return m.m
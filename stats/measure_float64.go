@@ -21,7 +21,8 @@ type Float64Measure struct {
 }
 
 // M creates a new float64 measurement.
-// Use Record to record measurements.
+// Use Record to record measurements. NaN and infinite values are dropped
+// by views rather than aggregated.
 func (m *Float64Measure) M(v float64) Measurement {
 	return Measurement{
 		m:    m,
@@ -35,10 +36,21 @@ func (m *Float64Measure) M(v float64) Measurement {
 // See the documentation for interface Measure for more guidance on the
 // parameters of this function.
 func Float64(name, description, unit string) *Float64Measure {
-	mi := registerMeasureHandle(name, description, unit)
+	mi := registerMeasureHandle(name, description, unit, func(d *measureDescriptor) Measure {
+		return &Float64Measure{d}
+	})
 	return &Float64Measure{mi}
 }
 
+// MustFloat64 is like Float64, but panics if name is not valid according to
+// ValidateMeasureName.
+func MustFloat64(name, description, unit string) *Float64Measure {
+	if err := ValidateMeasureName(name); err != nil {
+		panic(err)
+	}
+	return Float64(name, description, unit)
+}
+
 // Name returns the name of the measure.
 func (m *Float64Measure) Name() string {
 	return m.desc.name
@@ -27,6 +27,7 @@ func (m *Int64Measure) M(v int64) Measurement {
 		m:    m,
 		desc: m.desc,
 		v:    float64(v),
+		i:    v,
 	}
 }
 
@@ -35,10 +36,21 @@ func (m *Int64Measure) M(v int64) Measurement {
 // See the documentation for interface Measure for more guidance on the
 // parameters of this function.
 func Int64(name, description, unit string) *Int64Measure {
-	mi := registerMeasureHandle(name, description, unit)
+	mi := registerMeasureHandle(name, description, unit, func(d *measureDescriptor) Measure {
+		return &Int64Measure{d}
+	})
 	return &Int64Measure{mi}
 }
 
+// MustInt64 is like Int64, but panics if name is not valid according to
+// ValidateMeasureName.
+func MustInt64(name, description, unit string) *Int64Measure {
+	if err := ValidateMeasureName(name); err != nil {
+		panic(err)
+	}
+	return Int64(name, description, unit)
+}
+
 // Name returns the name of the measure.
 func (m *Int64Measure) Name() string {
 	return m.desc.name
@@ -0,0 +1,64 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package stats_test
+
+import (
+	"strings"
+	"testing"
+
+	"go.opencensus.io/stats"
+)
+
+func TestValidateMeasureName(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"example.com/measure/request_latency-ms", false},
+		{"request latency", true},
+		{"latencé", true},
+		{"", true},
+		{strings.Repeat("a", 256), true},
+	}
+	for _, tt := range tests {
+		err := stats.ValidateMeasureName(tt.name)
+		if gotErr := err != nil; gotErr != tt.wantErr {
+			t.Errorf("ValidateMeasureName(%q) = %v; want error: %t", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMustFloat64(t *testing.T) {
+	if m := stats.MustFloat64("TestMustFloat64/valid", "", stats.UnitDimensionless); m.Name() != "TestMustFloat64/valid" {
+		t.Errorf("MustFloat64 returned measure %q", m.Name())
+	}
+	for _, name := range []string{"TestMustFloat64 spaces", "TestMustFloat64/ünicode"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("MustFloat64(%q) did not panic", name)
+				}
+			}()
+			stats.MustFloat64(name, "", stats.UnitDimensionless)
+		}()
+	}
+	// Invalid measures are not registered.
+	for _, m := range stats.RegisteredMeasures() {
+		if strings.HasPrefix(m.Name(), "TestMustFloat64 ") {
+			t.Errorf("invalid measure %q was registered", m.Name())
+		}
+	}
+}
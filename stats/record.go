@@ -17,10 +17,13 @@ package stats
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/stats/internal"
 	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 )
 
 func init() {
@@ -29,6 +32,13 @@ func init() {
 		measures[measure].subscribe()
 		mu.Unlock()
 	}
+	internal.OnRecordDropped = func() func(interface{}) {
+		f, _ := onRecordDropped.Load().(func(Measure))
+		if f == nil {
+			return nil
+		}
+		return func(m interface{}) { f(m.(Measure)) }
+	}
 }
 
 // Recorder provides an interface for exporting measurement information from
@@ -39,20 +49,141 @@ type Recorder interface {
 	Record(*tag.Map, interface{}, map[string]interface{})
 }
 
+// spanContextExemplars is non-zero if span contexts are attached to recorded
+// measurements. Access atomically.
+var spanContextExemplars int32
+
+// SetSpanContextExemplars sets whether the span context of a sampled span in
+// the recording context is attached to recorded measurements, under the
+// metricdata.AttachmentKeySpanContext key. Distribution aggregations then keep
+// it in the exemplar of the bucket the measurement falls into, linking the
+// bucket to an example trace.
+//
+// It is disabled by default to avoid the cost of the context lookup.
+// Attachments given with WithAttachments take precedence.
+func SetSpanContextExemplars(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&spanContextExemplars, v)
+}
+
+// spanContextAttachments adds the span context of the sampled span in ctx,
+// if any, to attachments. It returns attachments unmodified if there is no
+// such span or attaching span contexts is disabled.
+func spanContextAttachments(ctx context.Context, attachments metricdata.Attachments) metricdata.Attachments {
+	if atomic.LoadInt32(&spanContextExemplars) == 0 {
+		return attachments
+	}
+	if _, ok := attachments[metricdata.AttachmentKeySpanContext]; ok {
+		return attachments
+	}
+	sc := trace.FromContext(ctx).SpanContext()
+	if !sc.IsSampled() {
+		return attachments
+	}
+	// Copy so that the caller's map is not modified.
+	a := make(metricdata.Attachments, len(attachments)+1)
+	for k, v := range attachments {
+		a[k] = v
+	}
+	a[metricdata.AttachmentKeySpanContext] = sc
+	return a
+}
+
+// onRecordDropped holds the func(Measure) set with SetOnRecordDropped.
+var onRecordDropped atomic.Value
+
+// SetOnRecordDropped sets a function called with the measure of each
+// recorded measurement that is discarded because no view is registered for
+// it, to help debug missing data. Passing nil removes the function.
+//
+// It is unset by default. The function is called synchronously by Record,
+// before the measurements are passed to the view worker, so it must be fast.
+// It must not record, as that would report the measurement again.
+func SetOnRecordDropped(f func(m Measure)) {
+	onRecordDropped.Store(f)
+}
+
+// reportDropped calls the function set with SetOnRecordDropped for each
+// measurement in ms whose measure has no subscribed view.
+func reportDropped(ms []Measurement) {
+	f, _ := onRecordDropped.Load().(func(Measure))
+	if f == nil {
+		return
+	}
+	for _, m := range ms {
+		if m.desc != nil && !m.desc.subscribed() {
+			f(m.m)
+		}
+	}
+}
+
 type recordOptions struct {
+	time         time.Time
 	attachments  metricdata.Attachments
 	mutators     []tag.Mutator
 	measurements []Measurement
 	recorder     Recorder
+
+	spanAnnotation bool
 }
 
 // WithAttachments applies provided exemplar attachments.
+//
+// Attachments are kept with the measurement as the exemplar of the bucket it
+// falls into. Only views with a Distribution aggregation consume
+// attachments for now; other aggregations ignore them.
 func WithAttachments(attachments metricdata.Attachments) Options {
 	return func(ro *recordOptions) {
 		ro.attachments = attachments
 	}
 }
 
+// WithTime records the measurements at time t rather than at the current
+// time, for example to backfill measurements from logs. Views aggregate
+// cumulatively regardless of the time of measurements, so t is only kept as
+// the timestamp of the exemplars of Distribution aggregations.
+//
+// WithTime is ignored if the Recorder given with WithRecorder does not
+// support recording at a given time; Meters returned by view.NewMeter do.
+func WithTime(t time.Time) Options {
+	return func(ro *recordOptions) {
+		ro.time = t
+	}
+}
+
+// WithSpanAnnotation annotates the sampled span in the recording context, if
+// any, with each recorded measurement, to correlate traces and metrics. The
+// annotations read "<measure name>=<value>".
+//
+// It is off by default, as annotating spans costs an allocation per
+// measurement.
+func WithSpanAnnotation() Options {
+	return func(ro *recordOptions) {
+		ro.spanAnnotation = true
+	}
+}
+
+// annotateSpan annotates the sampled span in ctx with ms.
+func annotateSpan(ctx context.Context, ms []Measurement) {
+	span := trace.FromContext(ctx)
+	if !span.SpanContext().IsSampled() {
+		return
+	}
+	for _, m := range ms {
+		if m.m == nil {
+			continue
+		}
+		if i, ok := m.Int64Value(); ok {
+			span.Annotatef(nil, "%s=%d", m.m.Name(), i)
+		} else {
+			span.Annotatef(nil, "%s=%v", m.m.Name(), m.v)
+		}
+	}
+}
+
 // WithTags applies provided tag mutators.
 func WithTags(mutators ...tag.Mutator) Options {
 	return func(ro *recordOptions) {
@@ -75,6 +206,13 @@ func WithRecorder(meter Recorder) Options {
 	}
 }
 
+// timedRecorder returns a recorder that records at time t with f.
+func timedRecorder(f func(*tag.Map, interface{}, map[string]interface{}, time.Time), t time.Time) func(*tag.Map, interface{}, map[string]interface{}) {
+	return func(tags *tag.Map, ms interface{}, attachments map[string]interface{}) {
+		f(tags, ms, attachments, t)
+	}
+}
+
 // Options apply changes to recordOptions.
 type Options func(*recordOptions)
 
@@ -110,9 +248,10 @@ func Record(ctx context.Context, ms ...Measurement) {
 		}
 	}
 	if !record {
+		reportDropped(ms)
 		return
 	}
-	recorder(tag.FromContext(ctx), ms, nil)
+	recorder(tag.FromContext(ctx), ms, spanContextAttachments(ctx, nil))
 	return
 }
 
@@ -122,7 +261,32 @@ func Record(ctx context.Context, ms ...Measurement) {
 // RecordWithTags is useful if you want to record with tag mutations but don't want
 // to propagate the mutations in the context.
 func RecordWithTags(ctx context.Context, mutators []tag.Mutator, ms ...Measurement) error {
-	return RecordWithOptions(ctx, WithTags(mutators...), WithMeasurements(ms...))
+	// Like Record, avoid the allocations of RecordWithOptions and of
+	// creating a context for the mutated tags.
+	if len(ms) == 0 {
+		return nil
+	}
+	recorder, initialized := internal.MeasurementRecorder.(measurementRecorder)
+	if !initialized {
+		return nil
+	}
+	record := false
+	for _, m := range ms {
+		if m.desc.subscribed() {
+			record = true
+			break
+		}
+	}
+	if !record {
+		reportDropped(ms)
+		return nil
+	}
+	tags, err := tag.NewMap(ctx, mutators...)
+	if err != nil {
+		return err
+	}
+	recorder(tags, ms, spanContextAttachments(ctx, nil))
+	return nil
 }
 
 // RecordWithOptions records measurements from the given options (if any) against context
@@ -133,10 +297,20 @@ func RecordWithOptions(ctx context.Context, ros ...Options) error {
 	if len(o.measurements) == 0 {
 		return nil
 	}
+	if o.spanAnnotation {
+		annotateSpan(ctx, o.measurements)
+	}
 	recorder := internal.DefaultRecorder
 	if o.recorder != nil {
 		recorder = o.recorder.Record
 	}
+	if !o.time.IsZero() {
+		if o.recorder == nil && internal.DefaultTimedRecorder != nil {
+			recorder = timedRecorder(internal.DefaultTimedRecorder, o.time)
+		} else if tr, ok := o.recorder.(internal.TimedRecorder); ok {
+			recorder = timedRecorder(tr.RecordAt, o.time)
+		}
+	}
 	if recorder == nil {
 		return nil
 	}
@@ -148,6 +322,7 @@ func RecordWithOptions(ctx context.Context, ros ...Options) error {
 		}
 	}
 	if !record {
+		reportDropped(o.measurements)
 		return nil
 	}
 	if len(o.mutators) > 0 {
@@ -156,6 +331,6 @@ func RecordWithOptions(ctx context.Context, ros ...Options) error {
 			return err
 		}
 	}
-	recorder(tag.FromContext(ctx), o.measurements, o.attachments)
+	recorder(tag.FromContext(ctx), o.measurements, spanContextAttachments(ctx, o.attachments))
 	return nil
 }
@@ -18,6 +18,8 @@ import (
 	"context"
 	"log"
 	"reflect"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -26,6 +28,7 @@ import (
 
 	"go.opencensus.io/metric/metricdata"
 	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/internal"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
@@ -90,11 +93,267 @@ func TestRecordWithAttachments(t *testing.T) {
 	}
 }
 
+func TestRecordWithTime(t *testing.T) {
+	m := stats.Int64("TestRecordWithTime/m", "", stats.UnitDimensionless)
+	v := &view.View{Name: "TestRecordWithTime", Measure: m, Aggregation: view.Distribution(5, 10)}
+	if err := view.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(v)
+
+	past := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	attachments := map[string]interface{}{"k": "v"}
+	ctx := context.Background()
+	stats.RecordWithOptions(ctx, stats.WithTime(past), stats.WithAttachments(attachments), stats.WithMeasurements(m.M(1)))
+	stats.RecordWithOptions(ctx, stats.WithAttachments(attachments), stats.WithMeasurements(m.M(7)))
+
+	rows, err := view.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	dis := rows[0].Data.(*view.DistributionData)
+	if want := []int64{1, 1, 0}; !reflect.DeepEqual(dis.CountPerBucket, want) {
+		t.Errorf("CountPerBucket = %v; want %v", dis.CountPerBucket, want)
+	}
+	if got := dis.ExemplarsPerBucket[0].Timestamp; !got.Equal(past) {
+		t.Errorf("exemplar timestamp = %v; want %v", got, past)
+	}
+	if got := dis.ExemplarsPerBucket[1].Timestamp; got.Before(time.Now().Add(-time.Minute)) {
+		t.Errorf("exemplar timestamp = %v; want the current time", got)
+	}
+}
+
+func TestBufferedRecorder(t *testing.T) {
+	m := stats.Int64("TestBufferedRecorder/m", "", stats.UnitDimensionless)
+	k := tag.MustNewKey("k")
+	v := &view.View{Name: "TestBufferedRecorder", Measure: m, TagKeys: []tag.Key{k}, Aggregation: view.Sum()}
+	if err := view.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(v)
+
+	ctx, _ := tag.New(context.Background(), tag.Upsert(k, "v"))
+	r := stats.NewRecorder(ctx)
+	for i := 1; i <= 100; i++ {
+		r.Record(m.M(int64(i)))
+	}
+	if rows, _ := view.RetrieveData(v.Name); len(rows) != 0 {
+		t.Fatalf("got %d rows before Flush; want 0", len(rows))
+	}
+	r.Flush()
+	r.Flush() // no-op
+
+	rows, err := view.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	if got, want := rows[0].Data.(*view.SumData).Value, 5050.0; got != want {
+		t.Errorf("sum = %v; want %v", got, want)
+	}
+	if want := []tag.Tag{{Key: k, Value: "v"}}; !reflect.DeepEqual(rows[0].Tags, want) {
+		t.Errorf("tags = %v; want %v", rows[0].Tags, want)
+	}
+}
+
+func TestRecordSpanContextExemplars(t *testing.T) {
+	m := stats.Int64("TestRecordSpanContextExemplars/m1", "", stats.UnitDimensionless)
+	v := &view.View{
+		Name:        "test_view_span_context_exemplars",
+		Measure:     m,
+		Aggregation: view.Distribution(5, 10),
+	}
+	if err := view.Register(v); err != nil {
+		t.Fatalf("Failed to register views: %v", err)
+	}
+	defer view.Unregister(v)
+
+	ctx, span := trace.StartSpan(context.Background(), "span", trace.WithSampler(trace.AlwaysSample()))
+	defer span.End()
+
+	// Disabled by default.
+	stats.Record(ctx, m.M(1))
+	stats.SetSpanContextExemplars(true)
+	defer stats.SetSpanContextExemplars(false)
+	stats.Record(ctx, m.M(7))
+	stats.RecordWithOptions(ctx, stats.WithMeasurements(m.M(12)))
+
+	rows, err := view.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatalf("Failed to retrieve data %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	dis := rows[0].Data.(*view.DistributionData)
+	if e := dis.ExemplarsPerBucket[0]; e != nil && len(e.Attachments) != 0 {
+		t.Errorf("got attachments %v with span context exemplars disabled, want none", e.Attachments)
+	}
+	attachments := metricdata.Attachments{metricdata.AttachmentKeySpanContext: span.SpanContext()}
+	for i, value := range []float64{7, 12} {
+		want := &metricdata.Exemplar{Value: value, Attachments: attachments}
+		if diff := cmpExemplar(dis.ExemplarsPerBucket[i+1], want); diff != "" {
+			t.Errorf("Unexpected Exemplar -got +want: %s", diff)
+		}
+	}
+}
+
 // Compare exemplars while ignoring exemplar timestamp, since timestamp is non-deterministic.
 func cmpExemplar(got, want *metricdata.Exemplar) string {
 	return cmp.Diff(got, want, cmpopts.IgnoreFields(metricdata.Exemplar{}, "Timestamp"), cmpopts.IgnoreUnexported(metricdata.Exemplar{}))
 }
 
+func TestRecordWithoutRecorder(t *testing.T) {
+	// Simulate a program that does not import the view package, in which
+	// case the recorder hooks are never set.
+	defaultRecorder, measurementRecorder := internal.DefaultRecorder, internal.MeasurementRecorder
+	internal.DefaultRecorder, internal.MeasurementRecorder = nil, nil
+	defer func() {
+		internal.DefaultRecorder, internal.MeasurementRecorder = defaultRecorder, measurementRecorder
+	}()
+
+	m := stats.Int64("TestRecordWithoutRecorder/m", "", stats.UnitDimensionless)
+	internal.SubscriptionReporter(m.Name())
+
+	ctx := context.Background()
+	stats.Record(ctx, m.M(1))
+	if err := stats.RecordWithTags(ctx, nil, m.M(1)); err != nil {
+		t.Errorf("RecordWithTags() = %v", err)
+	}
+	if err := stats.RecordWithOptions(ctx, stats.WithMeasurements(m.M(1))); err != nil {
+		t.Errorf("RecordWithOptions() = %v", err)
+	}
+}
+
+func TestSetOnRecordDropped(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		dropped []string
+	)
+	stats.SetOnRecordDropped(func(m stats.Measure) {
+		// Calling into the view package must not deadlock.
+		view.Find("TestSetOnRecordDropped")
+		mu.Lock()
+		dropped = append(dropped, m.Name())
+		mu.Unlock()
+	})
+	defer stats.SetOnRecordDropped(nil)
+
+	withView := stats.Int64("TestSetOnRecordDropped/with_view", "", stats.UnitDimensionless)
+	noView := stats.Int64("TestSetOnRecordDropped/no_view", "", stats.UnitDimensionless)
+	v := &view.View{Name: "TestSetOnRecordDropped", Measure: withView, Aggregation: view.Count()}
+	if err := view.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(v)
+
+	ctx := context.Background()
+	// Dropped before reaching the worker.
+	stats.Record(ctx, noView.M(1))
+	// Dropped even though withView has a view.
+	stats.Record(ctx, withView.M(1), noView.M(2))
+	// Wait for the worker to process the records.
+	if _, err := view.RetrieveData(v.Name); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := []string{noView.Name(), noView.Name()}; !reflect.DeepEqual(dropped, want) {
+		t.Errorf("dropped = %v; want %v", dropped, want)
+	}
+}
+
+type spanCollector []*trace.SpanData
+
+func (c *spanCollector) ExportSpan(sd *trace.SpanData) {
+	*c = append(*c, sd)
+}
+
+func TestStartTimedSpan(t *testing.T) {
+	k := tag.MustNewKey("TestStartTimedSpan/k")
+	m := stats.Float64("TestStartTimedSpan/latency", "", stats.UnitMilliseconds)
+	v := &view.View{Name: "TestStartTimedSpan", Measure: m, TagKeys: []tag.Key{k}, Aggregation: view.LastValue()}
+	if err := view.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	defer view.Unregister(v)
+
+	var spans spanCollector
+	trace.RegisterExporter(&spans)
+	defer trace.UnregisterExporter(&spans)
+
+	ctx, err := tag.New(context.Background(), tag.Insert(k, "v"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, end := stats.StartTimedSpan(ctx, "timed", m, trace.WithSampler(trace.AlwaysSample()))
+	time.Sleep(time.Millisecond)
+	end()
+	end()
+
+	if len(spans) != 1 {
+		t.Fatalf("got %d exported spans; want 1", len(spans))
+	}
+	rows, err := view.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	if got, want := rows[0].Tags, []tag.Tag{{Key: k, Value: "v"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Tags = %v; want %v", got, want)
+	}
+	sd := spans[0]
+	want := float64(sd.EndTime.Sub(sd.StartTime)) / float64(time.Millisecond)
+	if got := rows[0].Data.(*view.LastValueData).Value; got != want {
+		t.Errorf("recorded latency = %v; want span duration %v", got, want)
+	}
+
+	// Spans that do not record events are timed too.
+	_, end = stats.StartTimedSpan(ctx, "untimed", m, trace.WithSampler(trace.NeverSample()))
+	time.Sleep(time.Millisecond)
+	end()
+	if rows, err = view.RetrieveData(v.Name); err != nil {
+		t.Fatal(err)
+	}
+	if got := rows[0].Data.(*view.LastValueData).Value; got < 1 {
+		t.Errorf("recorded latency = %v; want at least 1ms", got)
+	}
+}
+
+func TestRecordWithSpanAnnotation(t *testing.T) {
+	count := stats.Int64("TestRecordWithSpanAnnotation/count", "", stats.UnitDimensionless)
+	latency := stats.Float64("TestRecordWithSpanAnnotation/latency", "", stats.UnitMilliseconds)
+
+	var spans spanCollector
+	trace.RegisterExporter(&spans)
+	defer trace.UnregisterExporter(&spans)
+
+	ctx, span := trace.StartSpan(context.Background(), "sampled", trace.WithSampler(trace.AlwaysSample()))
+	stats.RecordWithOptions(ctx, stats.WithSpanAnnotation(), stats.WithMeasurements(count.M(3), latency.M(1.5)))
+	stats.RecordWithOptions(ctx, stats.WithMeasurements(count.M(4)))
+	span.End()
+
+	if len(spans) != 1 {
+		t.Fatalf("got %d exported spans; want 1", len(spans))
+	}
+	var got []string
+	for _, a := range spans[0].Annotations {
+		got = append(got, a.Message)
+	}
+	want := []string{"TestRecordWithSpanAnnotation/count=3", "TestRecordWithSpanAnnotation/latency=1.5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("annotations = %q; want %q", got, want)
+	}
+}
+
 func TestRecordWithMeter(t *testing.T) {
 	meter := view.NewMeter()
 	meter.Start()
@@ -199,3 +458,28 @@ func TestRecordWithMeter(t *testing.T) {
 		t.Errorf("Wrong count for second_view, want %d, got %d", 1, gotCount.Value)
 	}
 }
+
+func TestRegisteredMeasures(t *testing.T) {
+	i := stats.Int64("TestRegisteredMeasures/int", "", stats.UnitDimensionless)
+	f := stats.Float64("TestRegisteredMeasures/float", "", stats.UnitMilliseconds)
+	stats.Float64("TestRegisteredMeasures/int", "", stats.UnitDimensionless) // already registered
+
+	got := make(map[string]stats.Measure)
+	var names []string
+	for _, m := range stats.RegisteredMeasures() {
+		got[m.Name()] = m
+		names = append(names, m.Name())
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("RegisteredMeasures() is not sorted by name: %v", names)
+	}
+	if len(got) != len(names) {
+		t.Errorf("RegisteredMeasures() has duplicates: %v", names)
+	}
+	if m, ok := got[i.Name()].(*stats.Int64Measure); !ok || m.Name() != i.Name() {
+		t.Errorf("RegisteredMeasures() has %v for %q; want an Int64Measure", got[i.Name()], i.Name())
+	}
+	if m, ok := got[f.Name()].(*stats.Float64Measure); !ok || m.Unit() != stats.UnitMilliseconds {
+		t.Errorf("RegisteredMeasures() has %v for %q; want a Float64Measure in ms", got[f.Name()], f.Name())
+	}
+}
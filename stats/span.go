@@ -0,0 +1,55 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// StartTimedSpan starts a span as trace.StartSpan does, and returns a
+// function that ends the span and records its duration in milliseconds to m,
+// tagged with the tags in ctx:
+//
+//	ctx, end := stats.StartTimedSpan(ctx, "db.query", queryLatencyMs)
+//	defer end()
+//
+// For spans that record events, the recorded duration is the span's
+// EndTime - StartTime. Other spans keep no timestamps, so the duration is
+// measured by StartTimedSpan itself. Calls to the function after the first
+// have no effect.
+func StartTimedSpan(ctx context.Context, name string, m *Float64Measure, o ...trace.StartOption) (context.Context, func()) {
+	start := time.Now()
+	ctx, span := trace.StartSpan(ctx, name, o...)
+	var (
+		d    time.Duration
+		once sync.Once
+	)
+	span.OnEnd(func(sd *trace.SpanData) {
+		d = sd.EndTime.Sub(sd.StartTime)
+	})
+	return ctx, func() {
+		once.Do(func() {
+			span.End()
+			if d == 0 {
+				d = time.Since(start)
+			}
+			Record(ctx, m.M(float64(d)/float64(time.Millisecond)))
+		})
+	}
+}
@@ -27,6 +27,8 @@ const (
 	AggTypeSum                         // the sum aggregation, see Sum.
 	AggTypeDistribution                // the distribution aggregation, see Distribution.
 	AggTypeLastValue                   // the last value aggregation, see LastValue.
+	AggTypeMinMax                      // the min/max aggregation, see MinMax.
+	AggTypeSummary                     // the summary aggregation, see Summary.
 )
 
 func (t AggType) String() string {
@@ -39,6 +41,8 @@ var aggTypeName = map[AggType]string{
 	AggTypeSum:          "Sum",
 	AggTypeDistribution: "Distribution",
 	AggTypeLastValue:    "LastValue",
+	AggTypeMinMax:       "MinMax",
+	AggTypeSummary:      "Summary",
 }
 
 // Aggregation represents a data aggregation method. Use one of the functions:
@@ -46,8 +50,13 @@ var aggTypeName = map[AggType]string{
 type Aggregation struct {
 	Type    AggType   // Type is the AggType of this Aggregation.
 	Buckets []float64 // Buckets are the bucket endpoints if this Aggregation represents a distribution, see Distribution.
+	// Quantiles are the quantiles estimated if this Aggregation represents
+	// a summary, see Summary.
+	Quantiles []float64
 
 	newData func(time.Time) AggregationData
+	// ratio is set for the views returned by Ratio.
+	ratio *ratio
 }
 
 var (
@@ -100,6 +109,10 @@ func Sum() *Aggregation {
 //
 // If len(bounds) is 1 then there is no finite buckets, and that single
 // element is the common boundary of the overflow and underflow buckets.
+//
+// The bounds must be finite, non-negative and strictly increasing,
+// otherwise registering a view with this aggregation fails. A leading
+// zero bound is dropped.
 func Distribution(bounds ...float64) *Aggregation {
 	agg := &Aggregation{
 		Type:    AggTypeDistribution,
@@ -111,6 +124,26 @@ func Distribution(bounds ...float64) *Aggregation {
 	return agg
 }
 
+var (
+	defaultLatencyBounds = []float64{0.01, 0.05, 0.1, 0.3, 0.6, 0.8, 1, 2, 3, 4, 5, 6, 8, 10, 13, 16, 20, 25, 30, 40, 50, 65, 80, 100, 130, 160, 200, 250, 300, 400, 500, 650, 800, 1000, 2000, 5000, 10000, 20000, 50000, 100000}
+	defaultSizeBounds    = []float64{1024, 2048, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864, 268435456, 1073741824, 4294967296}
+)
+
+// DefaultLatencyDistribution returns a distribution suited to latencies
+// recorded in milliseconds, with bounds from 10µs to 100s. It is the
+// distribution of the latency views of go.opencensus.io/plugin/ocgrpc.
+func DefaultLatencyDistribution() *Aggregation {
+	return Distribution(append([]float64(nil), defaultLatencyBounds...)...)
+}
+
+// DefaultSizeDistribution returns a distribution suited to sizes recorded
+// in bytes, with bounds from 1KiB to 4GiB. It is the distribution of the
+// size views of go.opencensus.io/plugin/ocgrpc and
+// go.opencensus.io/plugin/ochttp.
+func DefaultSizeDistribution() *Aggregation {
+	return Distribution(append([]float64(nil), defaultSizeBounds...)...)
+}
+
 // LastValue only reports the last value recorded using this
 // aggregation. All other measurements will be dropped.
 func LastValue() *Aggregation {
@@ -121,3 +154,42 @@ func LastValue() *Aggregation {
 		},
 	}
 }
+
+// MinMax keeps the minimum and maximum of the values recorded, a lighter
+// alternative to Distribution when only the extremes are of interest.
+//
+// When read as metrics, a view with this aggregation produces two gauges
+// named after the view with the suffixes "_min" and "_max".
+func MinMax() *Aggregation {
+	return &Aggregation{
+		Type: AggTypeMinMax,
+		newData: func(_ time.Time) AggregationData {
+			return &MinMaxData{}
+		},
+	}
+}
+
+// Summary indicates that the count and sum of the values recorded are kept,
+// along with an estimate of the given quantiles, such as 0.5, 0.95 and 0.99
+// for the median, p95 and p99. Unlike Distribution, no bucket bounds need to
+// be chosen for the values recorded.
+//
+// The estimate of quantile q is the value of a rank within n*e of q*n, where
+// n is the number of values recorded and e a tenth of the distance of q to 0
+// or 1, whichever is closer: 5% for the median, 0.1% for p99. Like other
+// aggregations, quantiles are computed over all the values recorded since
+// the view was registered.
+//
+// The quantiles must be strictly between 0 and 1, otherwise registering a
+// view with this aggregation fails. Views with this aggregation are read as
+// metrics of type metricdata.TypeSummary.
+func Summary(quantiles ...float64) *Aggregation {
+	agg := &Aggregation{
+		Type:      AggTypeSummary,
+		Quantiles: quantiles,
+	}
+	agg.newData = func(t time.Time) AggregationData {
+		return &SummaryData{Start: t, stream: newQuantileStream(agg.Quantiles)}
+	}
+	return agg
+}
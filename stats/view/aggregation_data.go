@@ -17,6 +17,7 @@ package view
 
 import (
 	"math"
+	"reflect"
 	"time"
 
 	"go.opencensus.io/metric/metricdata"
@@ -121,6 +122,42 @@ func (a *SumData) StartTime() time.Time {
 	return a.Start
 }
 
+// int64SumData aggregates the measurements of an Int64Measure for a Sum
+// view. It keeps their exact sum, which SumData.Value cannot represent
+// beyond 2^53, and reports it in its points; its rows hold a plain SumData.
+type int64SumData struct {
+	SumData
+	intValue int64
+	// hasFloat is set once a float64 sample is added, after which the sum
+	// is no longer exact.
+	hasFloat bool
+}
+
+func (a *int64SumData) addSample(v float64, attachments map[string]interface{}, t time.Time) {
+	a.SumData.addSample(v, attachments, t)
+	a.hasFloat = true
+}
+
+func (a *int64SumData) addInt64Sample(v int64) {
+	a.intValue += v
+	if a.hasFloat {
+		a.Value += float64(v)
+	} else {
+		a.Value = float64(a.intValue)
+	}
+}
+
+func (a *int64SumData) clone() AggregationData {
+	return a.SumData.clone()
+}
+
+func (a *int64SumData) toPoint(metricType metricdata.Type, t time.Time) metricdata.Point {
+	if metricType == metricdata.TypeCumulativeInt64 && !a.hasFloat {
+		return metricdata.NewInt64Point(t, a.intValue)
+	}
+	return a.SumData.toPoint(metricType, t)
+}
+
 // DistributionData is the aggregated data for the
 // Distribution aggregation.
 //
@@ -322,6 +359,123 @@ func (l *LastValueData) StartTime() time.Time {
 	return time.Time{}
 }
 
+// MinMaxData is the aggregated data for the MinMax aggregation.
+type MinMaxData struct {
+	Count    int64 // number of values recorded
+	Min, Max float64
+}
+
+func (a *MinMaxData) isAggregationData() bool { return true }
+
+func (a *MinMaxData) addSample(v float64, _ map[string]interface{}, _ time.Time) {
+	if a.Count == 0 || v < a.Min {
+		a.Min = v
+	}
+	if a.Count == 0 || v > a.Max {
+		a.Max = v
+	}
+	a.Count++
+}
+
+func (a *MinMaxData) clone() AggregationData {
+	c := *a
+	return &c
+}
+
+func (a *MinMaxData) equal(other AggregationData) bool {
+	a2, ok := other.(*MinMaxData)
+	if !ok {
+		return false
+	}
+	return *a == *a2
+}
+
+// toPoint is not supported, as the data is exported as two metrics; see
+// minMaxToMetrics.
+func (a *MinMaxData) toPoint(metricType metricdata.Type, t time.Time) metricdata.Point {
+	panic("unsupported metricdata.Type")
+}
+
+// StartTime returns an empty time value as the min and max are reported as
+// gauges.
+func (a *MinMaxData) StartTime() time.Time {
+	return time.Time{}
+}
+
+// SummaryData is the aggregated data for the Summary aggregation.
+type SummaryData struct {
+	Count int64
+	Sum   float64
+	// Quantiles maps the quantiles of the aggregation to their estimated
+	// value. It is set in the data reported to exporters.
+	Quantiles map[float64]float64
+	Start     time.Time
+
+	stream *quantileStream
+}
+
+func (a *SummaryData) isAggregationData() bool { return true }
+
+func (a *SummaryData) addSample(v float64, _ map[string]interface{}, _ time.Time) {
+	a.Count++
+	a.Sum += v
+	a.stream.insert(v)
+}
+
+func (a *SummaryData) clone() AggregationData {
+	c := &SummaryData{Count: a.Count, Sum: a.Sum, Start: a.Start}
+	if a.stream != nil {
+		c.Quantiles = make(map[float64]float64, len(a.stream.targets))
+		for _, t := range a.stream.targets {
+			c.Quantiles[t.q] = a.stream.query(t.q)
+		}
+	} else if a.Quantiles != nil {
+		c.Quantiles = make(map[float64]float64, len(a.Quantiles))
+		for q, v := range a.Quantiles {
+			c.Quantiles[q] = v
+		}
+	}
+	return c
+}
+
+func (a *SummaryData) equal(other AggregationData) bool {
+	a2, ok := other.(*SummaryData)
+	if !ok {
+		return false
+	}
+	return a.Start.Equal(a2.Start) && a.Count == a2.Count && a.Sum == a2.Sum &&
+		reflect.DeepEqual(a.Quantiles, a2.Quantiles)
+}
+
+func (a *SummaryData) toPoint(metricType metricdata.Type, t time.Time) metricdata.Point {
+	switch metricType {
+	case metricdata.TypeSummary:
+		percentiles := make(map[float64]float64, len(a.Quantiles))
+		for q, v := range a.Quantiles {
+			percentiles[percentile(q)] = v
+		}
+		return metricdata.NewSummaryPoint(t, &metricdata.Summary{
+			Count:          a.Count,
+			Sum:            a.Sum,
+			HasCountAndSum: true,
+			Snapshot:       metricdata.Snapshot{Percentiles: percentiles},
+		})
+	default:
+		panic("unsupported metricdata.Type")
+	}
+}
+
+// percentile returns the percentile of quantile q, rounded to 4 decimal
+// places so that, for example, 0.07 gives 7 rather than 7.000000000000001.
+func percentile(q float64) float64 {
+	return math.Round(q*1e6) / 1e4
+}
+
+// StartTime returns the start time of the data being aggregated by SummaryData.
+func (a *SummaryData) StartTime() time.Time {
+	return a.Start
+}
+
 // ClearStart clears the Start field from data if present. Useful for testing in cases where the
 // start time will be nondeterministic.
 func ClearStart(data AggregationData) {
@@ -332,5 +486,7 @@ func ClearStart(data AggregationData) {
 		data.Start = time.Time{}
 	case *DistributionData:
 		data.Start = time.Time{}
+	case *SummaryData:
+		data.Start = time.Time{}
 	}
 }
@@ -18,6 +18,7 @@ package view
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -115,3 +116,75 @@ func prepareContexts(tagCount int) []context.Context {
 
 	return ctxs
 }
+
+// BenchmarkRetrieveDataUnderLoad benchmarks RetrieveData while measurements
+// are being recorded concurrently.
+func BenchmarkRetrieveDataUnderLoad(b *testing.B) {
+	benchmarkRetrieveUnderLoad(b, (*worker).RetrieveData)
+}
+
+// BenchmarkRetrieveSnapshotUnderLoad benchmarks RetrieveSnapshot while
+// measurements are being recorded concurrently.
+func BenchmarkRetrieveSnapshotUnderLoad(b *testing.B) {
+	benchmarkRetrieveUnderLoad(b, (*worker).RetrieveSnapshot)
+}
+
+func benchmarkRetrieveUnderLoad(b *testing.B, retrieve func(*worker, string) ([]*Row, error)) {
+	meter := NewMeter().(*worker)
+	meter.Start()
+	defer meter.Stop()
+	meter.Register(view)
+	defer meter.Unregister(view)
+
+	ctxs := prepareContexts(10)
+	rec := stats.WithRecorder(meter)
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; ; j++ {
+				select {
+				case <-quit:
+					return
+				default:
+				}
+				stats.RecordWithOptions(ctxs[j%len(ctxs)], rec, stats.WithMeasurements(m.M(1)))
+			}
+		}()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := retrieve(meter, view.Name); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	close(quit)
+	wg.Wait()
+}
+
+// BenchmarkRecordKeylessCount benchmarks recording to a Count view without
+// tag keys.
+func BenchmarkRecordKeylessCount(b *testing.B) {
+	w := NewMeter().(*worker)
+	v := &View{Name: "keyless", Measure: m, Aggregation: Count()}
+	register := &registerViewReq{views: []*View{v}, err: make(chan error, 1)}
+	register.handleCommand(w)
+	if err := <-register.err; err != nil {
+		b.Fatal(err)
+	}
+	tm := tag.FromContext(prepareContexts(1)[0])
+	now := time.Now()
+	ms := []stats.Measurement{m.M(1)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		record := &recordReq{ms: ms, tm: tm, t: now}
+		record.handleCommand(w)
+	}
+}
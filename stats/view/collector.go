@@ -17,6 +17,7 @@ package view
 
 import (
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"go.opencensus.io/internal/tagencoding"
@@ -30,30 +31,87 @@ type collector struct {
 	// Aggregation is the description of the aggregation to perform for this
 	// view.
 	a *Aggregation
+	// maxRows is the maximum number of signatures, or <= 0 if unbounded.
+	maxRows int
+	// keyless caches the aggregator of the empty signature, the only one
+	// of views without tag keys.
+	keyless AggregationData
+	// exactSum is set for Sum views of Int64Measures, which are aggregated
+	// by an int64SumData.
+	exactSum bool
+}
+
+var droppedRowSamples int64 // accessed atomically
+
+// DroppedRowSamples returns the number of measurements dropped since the
+// program started because they would have created a new row in a view that
+// already had View.MaxRows rows.
+func DroppedRowSamples() int64 {
+	return atomic.LoadInt64(&droppedRowSamples)
 }
 
 func (c *collector) addSample(s string, v float64, attachments map[string]interface{}, t time.Time) {
+	if aggregator := c.aggregator(s, t); aggregator != nil {
+		aggregator.addSample(v, attachments, t)
+	}
+}
+
+// aggregator returns the aggregator of signature s, creating it if needed,
+// or nil if the row limit of the view is reached.
+func (c *collector) aggregator(s string, t time.Time) AggregationData {
 	aggregator, ok := c.signatures[s]
 	if !ok {
-		aggregator = c.a.newData(t)
+		if c.maxRows > 0 && len(c.signatures) >= c.maxRows {
+			atomic.AddInt64(&droppedRowSamples, 1)
+			return nil
+		}
+		aggregator = c.newData(t)
 		c.signatures[s] = aggregator
 	}
-	aggregator.addSample(v, attachments, t)
+	return aggregator
+}
+
+// keylessAggregator is a faster aggregator for views without tag keys,
+// which avoids looking up the aggregator once it exists.
+func (c *collector) keylessAggregator(t time.Time) AggregationData {
+	if c.keyless == nil {
+		c.keyless = c.newData(t)
+		c.signatures[""] = c.keyless
+	}
+	return c.keyless
+}
+
+func (c *collector) newData(t time.Time) AggregationData {
+	if c.exactSum {
+		return &int64SumData{SumData: SumData{Start: t}}
+	}
+	return c.a.newData(t)
 }
 
 // collectRows returns a snapshot of the collected Row values.
 func (c *collector) collectedRows(keys []tag.Key) []*Row {
+	return c.rows(keys, false)
+}
+
+// rows returns the collected Row values, holding copies of the aggregators.
+// If exact is set, int64SumData aggregators are held themselves instead, as
+// their copies lose the exact sum.
+func (c *collector) rows(keys []tag.Key, exact bool) []*Row {
 	rows := make([]*Row, 0, len(c.signatures))
 	for sig, aggregator := range c.signatures {
 		tags := decodeTags([]byte(sig), keys)
-		row := &Row{Tags: tags, Data: aggregator.clone()}
-		rows = append(rows, row)
+		data := aggregator
+		if _, ok := aggregator.(*int64SumData); !ok || !exact {
+			data = aggregator.clone()
+		}
+		rows = append(rows, &Row{Tags: tags, Data: data})
 	}
 	return rows
 }
 
 func (c *collector) clearRows() {
 	c.signatures = make(map[string]AggregationData)
+	c.keyless = nil
 }
 
 // encodeWithKeys encodes the map by using values
@@ -15,6 +15,8 @@
 package view_test
 
 import (
+	"context"
+	"fmt"
 	"log"
 
 	"go.opencensus.io/stats"
@@ -37,3 +39,39 @@ func Example() {
 
 	// Use view.RegisterExporter to export collected data.
 }
+
+func ExampleNewMeter() {
+	// A library can keep its views separate from the ones registered by the
+	// application by using its own Meter.
+	meter := view.NewMeter()
+	meter.Start()
+	defer meter.Stop()
+
+	m := stats.Int64("example.com/measure/requests", "requests", stats.UnitDimensionless)
+	v := &view.View{
+		Name:        "example.com/views/requests",
+		Measure:     m,
+		Aggregation: view.Count(),
+	}
+	if err := meter.Register(v); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := stats.RecordWithOptions(context.Background(),
+		stats.WithRecorder(meter),
+		stats.WithMeasurements(m.M(1), m.M(1))); err != nil {
+		log.Fatal(err)
+	}
+
+	rows, err := meter.RetrieveData(v.Name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(rows[0].Data.(*view.CountData).Value)
+
+	// The view is not visible through the package-level functions.
+	fmt.Println(view.Find(v.Name) == nil)
+	// Output:
+	// 2
+	// true
+}
@@ -14,6 +14,8 @@
 
 package view
 
+import "context"
+
 // Exporter exports the collected records as view data.
 //
 // The ExportView method should return quickly; if an
@@ -28,6 +30,12 @@ type Exporter interface {
 	ExportView(viewData *Data)
 }
 
+// Flusher is an optional interface that an Exporter can implement to export
+// the data it buffers when Shutdown is called.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
 // RegisterExporter registers an exporter.
 // Collected data will be reported via all the
 // registered exporters. Once you no longer
@@ -43,3 +51,15 @@ func RegisterExporter(e Exporter) {
 func UnregisterExporter(e Exporter) {
 	defaultWorker.UnregisterExporter(e)
 }
+
+// Shutdown reports the data collected for all registered views to the
+// registered exporters a last time, unregisters the exporters, and then calls
+// Flush on the exporters that implement Flusher. Exporters that do not
+// implement it are skipped.
+//
+// It returns the first error returned by Flush, or the error of ctx if ctx
+// is done before all exporters are flushed. It is intended to be called
+// once, when the program exits.
+func Shutdown(ctx context.Context) error {
+	return defaultWorker.Shutdown(ctx)
+}
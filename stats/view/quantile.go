@@ -0,0 +1,183 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The quantile estimation below is adapted from
+// github.com/beorn7/perks/quantile, which is distributed under the following
+// license:
+//
+// Copyright (C) 2013 Blake Mizerany
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files (the
+// "Software"), to deal in the Software without restriction, including
+// without limitation the rights to use, copy, modify, merge, publish,
+// distribute, sublicense, and/or sell copies of the Software, and to
+// permit persons to whom the Software is furnished to do so, subject to
+// the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE
+// LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION
+// OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION
+// WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package view
+
+import (
+	"math"
+	"sort"
+)
+
+// quantileStream estimates targeted quantiles of a stream of values in
+// bounded memory, using the algorithm of Cormode, Korn, Muthukrishnan and
+// Srivastava, "Effective Computation of Biased Quantiles over Data Streams".
+//
+// The estimate of quantile q is the value of rank r with
+// |r - q*n| <= eps*n, where eps is the error allowed for q.
+type quantileStream struct {
+	targets []quantileTarget
+	n       float64
+	samples []quantileSample
+	buffer  []float64 // values not merged into samples yet
+}
+
+type quantileTarget struct {
+	q, eps float64
+}
+
+// quantileSample is a value of the stream. width is the difference between
+// the lowest possible rank of the sample and that of the previous one, and
+// delta the difference between its highest and lowest possible ranks.
+type quantileSample struct {
+	value, width, delta float64
+}
+
+const quantileBufferSize = 500
+
+// newQuantileStream returns a stream estimating the given quantiles. The
+// error allowed for quantile q is a tenth of the distance of q to 0 or 1,
+// whichever is closer, so the median is estimated within 5% and p99 within
+// 0.1%.
+func newQuantileStream(quantiles []float64) *quantileStream {
+	s := &quantileStream{}
+	for _, q := range quantiles {
+		s.targets = append(s.targets, quantileTarget{q: q, eps: math.Min(q, 1-q) / 10})
+	}
+	return s
+}
+
+func (s *quantileStream) insert(v float64) {
+	s.buffer = append(s.buffer, v)
+	if len(s.buffer) == quantileBufferSize {
+		s.flush()
+	}
+}
+
+// query returns the estimate of quantile q, one of the targeted quantiles.
+func (s *quantileStream) query(q float64) float64 {
+	s.flush()
+	if len(s.samples) == 0 {
+		return 0
+	}
+	t := math.Ceil(q * s.n)
+	t += math.Ceil(s.invariant(t) / 2)
+	prev := s.samples[0]
+	var r float64
+	for _, c := range s.samples[1:] {
+		r += prev.width
+		if r+c.width+c.delta > t {
+			return prev.value
+		}
+		prev = c
+	}
+	return prev.value
+}
+
+// invariant returns the max allowed delta of a sample of rank r.
+func (s *quantileStream) invariant(r float64) float64 {
+	m := math.MaxFloat64
+	for _, t := range s.targets {
+		var f float64
+		if t.q*s.n <= r {
+			f = 2 * t.eps * r / t.q
+		} else {
+			f = 2 * t.eps * (s.n - r) / (1 - t.q)
+		}
+		m = math.Min(m, f)
+	}
+	return m
+}
+
+// flush merges the buffered values into the samples.
+func (s *quantileStream) flush() {
+	if len(s.buffer) == 0 {
+		return
+	}
+	sort.Float64s(s.buffer)
+	var r float64
+	i := 0
+	for _, v := range s.buffer {
+		inserted := false
+		for ; i < len(s.samples); i++ {
+			c := s.samples[i]
+			if c.value > v {
+				delta := math.Max(0, math.Floor(s.invariant(r))-1)
+				s.samples = append(s.samples, quantileSample{})
+				copy(s.samples[i+1:], s.samples[i:])
+				s.samples[i] = quantileSample{value: v, width: 1, delta: delta}
+				i++
+				inserted = true
+				break
+			}
+			r += c.width
+		}
+		if !inserted {
+			s.samples = append(s.samples, quantileSample{value: v, width: 1})
+			i++
+		}
+		s.n++
+		r++
+	}
+	s.buffer = s.buffer[:0]
+	s.compress()
+}
+
+// compress merges adjacent samples while keeping the error within bounds.
+func (s *quantileStream) compress() {
+	if len(s.samples) < 2 {
+		return
+	}
+	last := len(s.samples) - 1
+	x := s.samples[last]
+	xi := last
+	r := s.n - 1 - x.width
+	for i := last - 1; i >= 0; i-- {
+		c := s.samples[i]
+		if c.width+x.width+x.delta <= s.invariant(r) {
+			x.width += c.width
+			s.samples[xi] = x
+			s.samples = append(s.samples[:i], s.samples[i+1:]...)
+			xi--
+		} else {
+			x = c
+			xi = i
+		}
+		r -= c.width
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package view
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/stats"
+)
+
+func TestQuantileStream(t *testing.T) {
+	quantiles := []float64{0.5, 0.9, 0.95, 0.99}
+	s := newQuantileStream(quantiles)
+	const n = 100000
+	// The values are a shuffled 1..n, so quantile q is q*n.
+	for _, i := range rand.New(rand.NewSource(1)).Perm(n) {
+		s.insert(float64(i + 1))
+	}
+	for _, q := range quantiles {
+		got := s.query(q)
+		if tolerance := n * math.Min(q, 1-q) / 10; math.Abs(got-q*n) > tolerance {
+			t.Errorf("quantile %v = %v; want %v ± %v", q, got, q*n, tolerance)
+		}
+	}
+	if len(s.samples) > n/10 {
+		t.Errorf("kept %d samples out of %d values", len(s.samples), n)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	w := NewMeter().(*worker)
+	w.Start()
+	defer w.Stop()
+
+	m := stats.Float64("TestSummary", "", stats.UnitMilliseconds)
+	v := &View{Name: "TestSummary", Measure: m, Aggregation: Summary(0.07, 0.5, 0.99)}
+	if err := w.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= 1000; i++ {
+		stats.RecordWithOptions(context.Background(), stats.WithRecorder(w), stats.WithMeasurements(m.M(float64(i))))
+	}
+
+	rows, err := w.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	data := rows[0].Data.(*SummaryData)
+	if data.Count != 1000 || data.Sum != 500500 {
+		t.Errorf("count, sum = %v, %v; want 1000, 500500", data.Count, data.Sum)
+	}
+	if got := data.Quantiles[0.5]; math.Abs(got-500) > 50 {
+		t.Errorf("median = %v; want 500 ± 50", got)
+	}
+	// The rank error of p99 is 1, plus 1 for rounding ranks.
+	if got := data.Quantiles[0.99]; math.Abs(got-990) > 2 {
+		t.Errorf("p99 = %v; want 990 ± 2", got)
+	}
+
+	metrics := w.Read()
+	if len(metrics) != 1 || metrics[0].Descriptor.Type != metricdata.TypeSummary {
+		t.Fatalf("got metrics %v; want a single summary", metrics)
+	}
+	summary := metrics[0].TimeSeries[0].Points[0].Value.(*metricdata.Summary)
+	// 0.07*100 is 7.000000000000001 in floating point.
+	for q, p := range map[float64]float64{0.07: 7, 0.5: 50, 0.99: 99} {
+		if got, ok := summary.Snapshot.Percentiles[p]; !ok || got != data.Quantiles[q] {
+			t.Errorf("percentiles = %v; want %v at %v", summary.Snapshot.Percentiles, data.Quantiles[q], p)
+		}
+	}
+
+	for _, q := range []float64{0, 1, -0.5, math.NaN()} {
+		bad := &View{Name: "TestSummary/bad", Measure: m, Aggregation: Summary(q)}
+		if err := w.Register(bad); err == nil {
+			t.Errorf("Register() of a summary of quantile %v = nil; want an error", q)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package view
+
+import (
+	"fmt"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+// ratio describes a view derived from two other views.
+type ratio struct {
+	numerator, denominator *View
+	zero                   float64
+}
+
+// Ratio returns a view whose rows are the values of the rows of numerator
+// divided by the values of the rows of denominator with the same tags, for
+// example an error rate computed from a count of errors and a count of
+// requests:
+//
+//	errorRate := view.Ratio(errorCountView, requestCountView).WithName("error_rate")
+//
+// The ratio is computed whenever the view is reported or retrieved, and
+// reported as a LastValue aggregation. The values of Count, Sum and LastValue
+// rows are used as is; Distribution and Summary rows contribute their Count.
+// Rows of numerator without a matching row in denominator are skipped, and
+// rows with a zero denominator have a value of 0; use RatioWithZero to change
+// that.
+//
+// numerator and denominator must have the same TagKeys, and must be
+// registered for the ratio view to have rows. The returned view is named
+// "<numerator>_per_<denominator>" and has the TagKeys of numerator.
+func Ratio(numerator, denominator *View) *View {
+	return RatioWithZero(numerator, denominator, 0)
+}
+
+// RatioWithZero is like Ratio, but rows with a zero denominator are reported
+// with the value zero instead of 0, for example math.NaN().
+func RatioWithZero(numerator, denominator *View, zero float64) *View {
+	name := numerator.Name + "_per_" + denominator.Name
+	desc := fmt.Sprintf("ratio of %s to %s", numerator.Name, denominator.Name)
+	agg := LastValue()
+	agg.ratio = &ratio{numerator: numerator, denominator: denominator, zero: zero}
+	return &View{
+		Name:        name,
+		Description: desc,
+		TagKeys:     append([]tag.Key(nil), numerator.TagKeys...),
+		Measure:     &ratioMeasure{name: name, description: desc},
+		Aggregation: agg,
+	}
+}
+
+// ratioMeasure is the measure of the views returned by Ratio. Nothing is
+// recorded for it, so it is not registered with the stats package and does
+// not take up a measure name.
+type ratioMeasure struct {
+	name, description string
+}
+
+func (m *ratioMeasure) Name() string        { return m.name }
+func (m *ratioMeasure) Description() string { return m.description }
+func (m *ratioMeasure) Unit() string        { return stats.UnitDimensionless }
+
+// check returns an error if the ratio cannot be computed for v.
+func (r *ratio) check(v *View) error {
+	if !sameKeys(r.numerator.TagKeys, r.denominator.TagKeys) || !sameKeys(v.TagKeys, r.numerator.TagKeys) {
+		return fmt.Errorf("cannot register view %q: numerator and denominator views must have the same tag keys", v.Name)
+	}
+	return nil
+}
+
+// sameKeys reports whether a and b hold the same keys, in any order.
+func sameKeys(a, b []tag.Key) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	names := make(map[string]bool, len(a))
+	for _, k := range a {
+		names[k.Name()] = true
+	}
+	for _, k := range b {
+		if !names[k.Name()] {
+			return false
+		}
+	}
+	return true
+}
+
+// ratioRows computes the rows of the ratio view v from the data collected by
+// the registered numerator and denominator views.
+func (w *worker) ratioRows(v *View, r *ratio) []*Row {
+	num, ok := w.views[r.numerator.Name]
+	if !ok || !num.isSubscribed() {
+		return nil
+	}
+	den, ok := w.views[r.denominator.Name]
+	if !ok || !den.isSubscribed() {
+		return nil
+	}
+	if !sameKeys(num.view.TagKeys, v.TagKeys) || !sameKeys(den.view.TagKeys, v.TagKeys) {
+		return nil
+	}
+	// The keys of all three views are sorted by canonicalize, so rows with
+	// the same tags have the same signature.
+	var rows []*Row
+	for sig, n := range num.collector.signatures {
+		d, ok := den.collector.signatures[sig]
+		if !ok {
+			continue
+		}
+		value := r.zero
+		if dv := ratioValue(d); dv != 0 {
+			value = ratioValue(n) / dv
+		}
+		rows = append(rows, &Row{
+			Tags: decodeTags([]byte(sig), v.TagKeys),
+			Data: &LastValueData{Value: value},
+		})
+	}
+	return rows
+}
+
+// ratioValue returns the value a row contributes to a ratio.
+func ratioValue(data AggregationData) float64 {
+	switch data := data.(type) {
+	case *CountData:
+		return float64(data.Value)
+	case *SumData:
+		return data.Value
+	case *int64SumData:
+		return data.Value
+	case *LastValueData:
+		return data.Value
+	case *DistributionData:
+		return float64(data.Count)
+	case *SummaryData:
+		return float64(data.Count)
+	}
+	return 0
+}
@@ -0,0 +1,102 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package view
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+func TestRatio(t *testing.T) {
+	errs := stats.Int64("TestRatio/errors", "", stats.UnitDimensionless)
+	reqs := stats.Int64("TestRatio/requests", "", stats.UnitDimensionless)
+	k := tag.MustNewKey("route")
+	errView := &View{Name: "TestRatio/errors", Measure: errs, TagKeys: []tag.Key{k}, Aggregation: Count()}
+	reqView := &View{Name: "TestRatio/requests", Measure: reqs, TagKeys: []tag.Key{k}, Aggregation: Sum()}
+	ratio := Ratio(errView, reqView).WithName("TestRatio/error_rate")
+	nanRatio := RatioWithZero(errView, reqView, math.NaN())
+
+	m := NewMeter()
+	m.Start()
+	defer m.Stop()
+	if err := m.Register(errView, reqView, ratio, nanRatio); err != nil {
+		t.Fatal(err)
+	}
+
+	record := func(route string, ms ...stats.Measurement) {
+		ctx, _ := tag.New(context.Background(), tag.Upsert(k, route))
+		if err := stats.RecordWithOptions(ctx, stats.WithRecorder(m), stats.WithMeasurements(ms...)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	record("/a", reqs.M(4), errs.M(1))
+	record("/a", errs.M(1))
+	record("/b", reqs.M(0), errs.M(1))
+	record("/c", errs.M(1)) // no requests, skipped
+
+	rows, err := m.RetrieveData(ratio.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]float64)
+	for _, r := range rows {
+		got[r.Tags[0].Value] = r.Data.(*LastValueData).Value
+	}
+	want := map[string]float64{"/a": 0.5, "/b": 0}
+	if len(got) != len(want) {
+		t.Fatalf("got rows %v; want %v", got, want)
+	}
+	for route, v := range want {
+		if got[route] != v {
+			t.Errorf("ratio for %q = %v; want %v", route, got[route], v)
+		}
+	}
+
+	rows, err = m.RetrieveData(nanRatio.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rows {
+		if v := r.Data.(*LastValueData).Value; r.Tags[0].Value == "/b" && !math.IsNaN(v) {
+			t.Errorf("ratio for /b = %v; want NaN", v)
+		}
+	}
+}
+
+func TestRatioMeasureNotRegistered(t *testing.T) {
+	m := stats.Int64("TestRatioMeasureNotRegistered", "", stats.UnitDimensionless)
+	num := &View{Name: "TestRatioMeasureNotRegistered/num", Measure: m, Aggregation: Count()}
+	den := &View{Name: "TestRatioMeasureNotRegistered/den", Measure: m, Aggregation: Count()}
+	ratio := Ratio(num, den)
+	for _, measure := range stats.RegisteredMeasures() {
+		if measure.Name() == ratio.Measure.Name() {
+			t.Errorf("measure %q of the ratio view is registered", measure.Name())
+		}
+	}
+}
+
+func TestRatioMismatchedKeys(t *testing.T) {
+	m := stats.Int64("TestRatioMismatchedKeys", "", stats.UnitDimensionless)
+	num := &View{Name: "TestRatioMismatchedKeys/num", Measure: m, TagKeys: []tag.Key{tag.MustNewKey("a")}, Aggregation: Count()}
+	den := &View{Name: "TestRatioMismatchedKeys/den", Measure: m, Aggregation: Count()}
+	if err := Register(Ratio(num, den)); err == nil {
+		t.Error("Register() = nil; want an error for views with different tag keys")
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"sync/atomic"
@@ -45,6 +46,13 @@ type View struct {
 
 	// Aggregation is the aggregation function to apply to the set of Measurements.
 	Aggregation *Aggregation
+
+	// MaxRows limits the number of distinct combinations of tag values,
+	// and so the number of Rows, tracked for this view. Once the limit is
+	// reached, measurements for new combinations are dropped and counted in
+	// DroppedRowSamples, while existing rows keep being updated.
+	// If zero or negative, the number of rows is unbounded.
+	MaxRows int
 }
 
 // WithName returns a copy of the View with a new name. This is useful for
@@ -94,11 +102,26 @@ func (v *View) canonicalize() error {
 	sort.Slice(v.TagKeys, func(i, j int) bool {
 		return v.TagKeys[i].Name() < v.TagKeys[j].Name()
 	})
-	sort.Float64s(v.Aggregation.Buckets)
-	for _, b := range v.Aggregation.Buckets {
+	for i, b := range v.Aggregation.Buckets {
+		if math.IsNaN(b) || math.IsInf(b, 0) {
+			return fmt.Errorf("cannot register view %q: bucket bound %v at index %d is not finite", v.Name, b, i)
+		}
 		if b < 0 {
 			return ErrNegativeBucketBounds
 		}
+		if i > 0 && b <= v.Aggregation.Buckets[i-1] {
+			return fmt.Errorf("cannot register view %q: bucket bound %v at index %d is not greater than the previous bound %v", v.Name, b, i, v.Aggregation.Buckets[i-1])
+		}
+	}
+	for _, q := range v.Aggregation.Quantiles {
+		if !(q > 0 && q < 1) {
+			return fmt.Errorf("cannot register view %q: quantile %v is not between 0 and 1", v.Name, q)
+		}
+	}
+	if r := v.Aggregation.ratio; r != nil {
+		if err := r.check(v); err != nil {
+			return err
+		}
 	}
 	// drop 0 bucket silently.
 	v.Aggregation.Buckets = dropZeroBounds(v.Aggregation.Buckets...)
@@ -121,16 +144,30 @@ type viewInternal struct {
 	subscribed       uint32 // 1 if someone is subscribed and data need to be exported, use atomic to access
 	collector        *collector
 	metricDescriptor *metricdata.Descriptor
+	// derive, if set, computes the rows of a view that does not collect
+	// measurements itself, see Ratio.
+	derive func() []*Row
 }
 
 func newViewInternal(v *View) (*viewInternal, error) {
 	return &viewInternal{
-		view:             v,
-		collector:        &collector{make(map[string]AggregationData), v.Aggregation},
+		view: v,
+		collector: &collector{
+			signatures: make(map[string]AggregationData),
+			a:          v.Aggregation,
+			maxRows:    v.MaxRows,
+			exactSum:   isInt64Sum(v),
+		},
 		metricDescriptor: viewToMetricDescriptor(v),
 	}, nil
 }
 
+// isInt64Sum reports whether v sums the measurements of an Int64Measure.
+func isInt64Sum(v *View) bool {
+	_, ok := v.Measure.(*stats.Int64Measure)
+	return ok && v.Aggregation.Type == AggTypeSum
+}
+
 func (v *viewInternal) subscribe() {
 	atomic.StoreUint32(&v.subscribed, 1)
 }
@@ -150,15 +187,51 @@ func (v *viewInternal) clearRows() {
 }
 
 func (v *viewInternal) collectedRows() []*Row {
+	if v.derive != nil {
+		return v.derive()
+	}
 	return v.collector.collectedRows(v.view.TagKeys)
 }
 
+// metricRows is like collectedRows, but the rows of exact sums hold the
+// aggregators themselves, whose points are more precise than those of their
+// copies. They must not be used once the worker lock is released.
+func (v *viewInternal) metricRows() []*Row {
+	if v.derive != nil {
+		return v.derive()
+	}
+	return v.collector.rows(v.view.TagKeys, true)
+}
+
 func (v *viewInternal) addSample(m *tag.Map, val float64, attachments map[string]interface{}, t time.Time) {
+	if a := v.aggregator(m, t); a != nil {
+		a.addSample(val, attachments, t)
+	}
+}
+
+// addInt64Sample is like addSample for measurements of an Int64Measure. Sums
+// of such measurements are kept exact.
+func (v *viewInternal) addInt64Sample(m *tag.Map, val int64, attachments map[string]interface{}, t time.Time) {
+	switch a := v.aggregator(m, t).(type) {
+	case nil:
+	case *int64SumData:
+		a.addInt64Sample(val)
+	default:
+		a.addSample(float64(val), attachments, t)
+	}
+}
+
+// aggregator returns the aggregator for the tags in m, or nil if the view
+// is not collecting or has reached its row limit.
+func (v *viewInternal) aggregator(m *tag.Map, t time.Time) AggregationData {
 	if !v.isSubscribed() {
-		return
+		return nil
+	}
+	if len(v.view.TagKeys) == 0 {
+		return v.collector.keylessAggregator(t)
 	}
 	sig := string(encodeWithKeys(m, v.view.TagKeys))
-	v.collector.addSample(sig, val, attachments, t)
+	return v.collector.aggregator(sig, t)
 }
 
 // A Data is a set of rows about usage of the single measure associated
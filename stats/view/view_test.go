@@ -17,6 +17,8 @@ package view
 
 import (
 	"context"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -430,6 +432,43 @@ func TestRegisterAfterMeasurement(t *testing.T) {
 	}
 }
 
+func TestViewMaxRows(t *testing.T) {
+	m := stats.Int64(t.Name(), "", stats.UnitDimensionless)
+	k := tag.MustNewKey("id")
+	v := &View{
+		Measure:     m,
+		TagKeys:     []tag.Key{k},
+		Aggregation: Count(),
+		MaxRows:     2,
+	}
+	if err := Register(v); err != nil {
+		t.Fatal(err)
+	}
+	defer Unregister(v)
+
+	dropped := DroppedRowSamples()
+	for _, id := range []string{"a", "b", "c", "a", "d"} {
+		ctx, _ := tag.New(context.Background(), tag.Upsert(k, id))
+		stats.Record(ctx, m.M(1))
+	}
+
+	rows, err := RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]int64)
+	for _, r := range rows {
+		got[r.Tags[0].Value] = r.Data.(*CountData).Value
+	}
+	want := map[string]int64{"a": 2, "b": 1}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("rows (-got +want): %s", diff)
+	}
+	if got, want := DroppedRowSamples()-dropped, int64(2); got != want {
+		t.Errorf("DroppedRowSamples() increased by %d; want %d", got, want)
+	}
+}
+
 func TestViewRegister_negativeBucketBounds(t *testing.T) {
 	m := stats.Int64("TestViewRegister_negativeBucketBounds", "", "")
 	v := &View{
@@ -442,19 +481,53 @@ func TestViewRegister_negativeBucketBounds(t *testing.T) {
 	}
 }
 
-func TestViewRegister_sortBuckets(t *testing.T) {
-	m := stats.Int64("TestViewRegister_sortBuckets", "", "")
-	v := &View{
-		Measure:     m,
-		Aggregation: Distribution(2, 1),
-	}
-	err := Register(v)
-	if err != nil {
-		t.Fatalf("Unexpected err %s", err)
+func TestViewRegister_invalidBucketBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		bounds  []float64
+		wantErr string
+	}{
+		{
+			name:    "unsorted",
+			bounds:  []float64{2, 1},
+			wantErr: "bucket bound 1 at index 1 is not greater than the previous bound 2",
+		},
+		{
+			name:    "duplicate",
+			bounds:  []float64{1, 2, 2, 3},
+			wantErr: "bucket bound 2 at index 2 is not greater than the previous bound 2",
+		},
+		{
+			name:    "duplicate zero",
+			bounds:  []float64{0, 0, 1},
+			wantErr: "bucket bound 0 at index 1 is not greater than the previous bound 0",
+		},
+		{
+			name:    "NaN",
+			bounds:  []float64{1, math.NaN()},
+			wantErr: "bucket bound NaN at index 1 is not finite",
+		},
+		{
+			name:    "infinite",
+			bounds:  []float64{1, math.Inf(1)},
+			wantErr: "bucket bound +Inf at index 1 is not finite",
+		},
 	}
-	want := []float64{1, 2}
-	if diff := cmp.Diff(v.Aggregation.Buckets, want); diff != "" {
-		t.Errorf("buckets differ -got +want: %s", diff)
+	for _, tt := range tests {
+		m := stats.Int64("TestViewRegister_invalidBucketBounds_"+tt.name, "", "")
+		v := &View{
+			Measure:     m,
+			Aggregation: Distribution(tt.bounds...),
+		}
+		err := Register(v)
+		if err == nil {
+			Unregister(v)
+			t.Errorf("%s: Register() = nil; want error", tt.name)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: Register() = %q; want error containing %q", tt.name, err, tt.wantErr)
+		}
 	}
 }
 
@@ -462,7 +535,7 @@ func TestViewRegister_dropZeroBuckets(t *testing.T) {
 	m := stats.Int64("TestViewRegister_dropZeroBuckets", "", "")
 	v := &View{
 		Measure:     m,
-		Aggregation: Distribution(2, 0, 1),
+		Aggregation: Distribution(0, 1, 2),
 	}
 	err := Register(v)
 	if err != nil {
@@ -473,3 +546,75 @@ func TestViewRegister_dropZeroBuckets(t *testing.T) {
 		t.Errorf("buckets differ -got +want: %s", diff)
 	}
 }
+
+func TestDefaultDistributions(t *testing.T) {
+	tests := []struct {
+		name string
+		agg  func() *Aggregation
+		want []float64
+	}{
+		{
+			name: "latency",
+			agg:  DefaultLatencyDistribution,
+			want: []float64{0.01, 0.05, 0.1, 0.3, 0.6, 0.8, 1, 2, 3, 4, 5, 6, 8, 10, 13, 16, 20, 25, 30, 40, 50, 65, 80, 100, 130, 160, 200, 250, 300, 400, 500, 650, 800, 1000, 2000, 5000, 10000, 20000, 50000, 100000},
+		},
+		{
+			name: "size",
+			agg:  DefaultSizeDistribution,
+			want: []float64{1024, 2048, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864, 268435456, 1073741824, 4294967296},
+		},
+	}
+	for _, tt := range tests {
+		agg := tt.agg()
+		if agg.Type != AggTypeDistribution {
+			t.Errorf("%s: Type = %v; want %v", tt.name, agg.Type, AggTypeDistribution)
+		}
+		if diff := cmp.Diff(agg.Buckets, tt.want); diff != "" {
+			t.Errorf("%s: Buckets (-got +want): %s", tt.name, diff)
+		}
+		agg.Buckets[0] = -1
+		if got := tt.agg().Buckets[0]; got != tt.want[0] {
+			t.Errorf("%s: modifying the buckets of one aggregation changed the next one to %v", tt.name, got)
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	w := NewMeter().(*worker)
+	w.Start()
+	defer w.Stop()
+
+	m := stats.Float64("TestMinMax", "", stats.UnitMilliseconds)
+	k := tag.MustNewKey("k")
+	v := &View{Name: "TestMinMax", Measure: m, TagKeys: []tag.Key{k}, Aggregation: MinMax()}
+	if err := w.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	ctx, _ := tag.New(context.Background(), tag.Upsert(k, "v"))
+	for _, x := range []float64{3, -1.5, 7, 2} {
+		stats.RecordWithOptions(ctx, stats.WithRecorder(w), stats.WithMeasurements(m.M(x)))
+	}
+
+	rows, err := w.RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	want := &MinMaxData{Count: 4, Min: -1.5, Max: 7}
+	if diff := cmp.Diff(rows[0].Data, want); diff != "" {
+		t.Errorf("data (-got +want): %s", diff)
+	}
+
+	got := make(map[string]interface{})
+	for _, metric := range w.Read() {
+		if metric.Descriptor.Type != metricdata.TypeGaugeFloat64 {
+			t.Errorf("metric %q has type %v; want %v", metric.Descriptor.Name, metric.Descriptor.Type, metricdata.TypeGaugeFloat64)
+		}
+		got[metric.Descriptor.Name] = metric.TimeSeries[0].Points[0].Value
+	}
+	if diff := cmp.Diff(got, map[string]interface{}{"TestMinMax_min": -1.5, "TestMinMax_max": 7.0}); diff != "" {
+		t.Errorf("metrics (-got +want): %s", diff)
+	}
+}
@@ -52,11 +52,13 @@ func getType(v *View) metricdata.Type {
 		}
 	case AggTypeDistribution:
 		return metricdata.TypeCumulativeDistribution
-	case AggTypeLastValue:
+	case AggTypeSummary:
+		return metricdata.TypeSummary
+	case AggTypeLastValue, AggTypeMinMax:
 		switch m.(type) {
 		case *stats.Int64Measure:
 			return metricdata.TypeGaugeInt64
-		case *stats.Float64Measure:
+		case *stats.Float64Measure, *ratioMeasure:
 			return metricdata.TypeGaugeFloat64
 		default:
 			panic("unexpected measure type")
@@ -128,7 +130,7 @@ func rowToTimeseries(v *viewInternal, row *Row, now time.Time) *metricdata.TimeS
 }
 
 func viewToMetric(v *viewInternal, r *resource.Resource, now time.Time) *metricdata.Metric {
-	rows := v.collectedRows()
+	rows := v.metricRows()
 	if len(rows) == 0 {
 		return nil
 	}
@@ -145,3 +147,35 @@ func viewToMetric(v *viewInternal, r *resource.Resource, now time.Time) *metricd
 	}
 	return m
 }
+
+// minMaxToMetrics converts the data of a view with a MinMax aggregation to a
+// gauge of the minimums and a gauge of the maximums.
+func minMaxToMetrics(v *viewInternal, r *resource.Resource, now time.Time) []*metricdata.Metric {
+	rows := v.collectedRows()
+	if len(rows) == 0 {
+		return nil
+	}
+	min := &metricdata.Metric{Descriptor: *v.metricDescriptor, Resource: r}
+	min.Descriptor.Name += "_min"
+	max := &metricdata.Metric{Descriptor: *v.metricDescriptor, Resource: r}
+	max.Descriptor.Name += "_max"
+	point := func(v float64) metricdata.Point {
+		if min.Descriptor.Type == metricdata.TypeGaugeInt64 {
+			return metricdata.NewInt64Point(now, int64(v))
+		}
+		return metricdata.NewFloat64Point(now, v)
+	}
+	for _, row := range rows {
+		data := row.Data.(*MinMaxData)
+		labels := toLabelValues(row, v.metricDescriptor.LabelKeys)
+		min.TimeSeries = append(min.TimeSeries, &metricdata.TimeSeries{
+			Points:      []metricdata.Point{point(data.Min)},
+			LabelValues: labels,
+		})
+		max.TimeSeries = append(max.TimeSeries, &metricdata.TimeSeries{
+			Points:      []metricdata.Point{point(data.Max)},
+			LabelValues: labels,
+		})
+	}
+	return []*metricdata.Metric{min, max}
+}
@@ -524,7 +524,7 @@ func (me *mockExp) ExportMetrics(ctx context.Context, metrics []*metricdata.Metr
 
 var _ metricexport.Exporter = (*mockExp)(nil)
 
-func TestViewToMetric_OutOfOrderWithZeroBuckets(t *testing.T) {
+func TestViewToMetric_WithZeroBuckets(t *testing.T) {
 	m := stats.Int64("OutOfOrderWithZeroBuckets", "", "")
 	now := time.Now()
 	tts := []struct {
@@ -535,7 +535,7 @@ func TestViewToMetric_OutOfOrderWithZeroBuckets(t *testing.T) {
 			v: &View{
 				Name:        m.Name() + "_order1",
 				Measure:     m,
-				Aggregation: Distribution(10, 0, 2),
+				Aggregation: Distribution(0, 2, 10),
 			},
 			m: &metricdata.Metric{
 				Descriptor: metricdata.Descriptor{
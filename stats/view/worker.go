@@ -16,7 +16,9 @@
 package view
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -33,6 +35,7 @@ func init() {
 	defaultWorker = NewMeter().(*worker)
 	go defaultWorker.start()
 	internal.DefaultRecorder = record
+	internal.DefaultTimedRecorder = recordAt
 	internal.MeasurementRecorder = recordMeasurement
 }
 
@@ -46,7 +49,17 @@ type worker struct {
 	views          map[string]*viewInternal
 	viewStartTimes map[*viewInternal]time.Time
 
-	timer      *time.Ticker
+	// reportingPeriod is the default interval between reports. Views with an
+	// entry in viewReportingPeriods are reported on their own cadence instead,
+	// tracked by viewNextReports. The timer ticks at the smallest of all periods.
+	reportingPeriod      time.Duration
+	nextReport           time.Time
+	viewReportingPeriods map[string]time.Duration
+	viewNextReports      map[string]time.Time
+	tickPeriod           time.Duration
+
+	clock      clock
+	timer      ticker
 	c          chan command
 	quit, done chan bool
 	mu         sync.RWMutex
@@ -54,6 +67,11 @@ type worker struct {
 
 	exportersMu sync.RWMutex
 	exporters   map[Exporter]struct{}
+
+	// snapshots holds the rows last reported for each registered view, so
+	// they can be read without going through the worker goroutine.
+	snapshotsMu sync.RWMutex
+	snapshots   map[string][]*Row
 }
 
 // Meter defines an interface which allows a single process to maintain
@@ -61,6 +79,11 @@ type worker struct {
 // single process wants to report metrics about multiple objects, such as
 // multiple databases or HTTP services).
 //
+// The static functions in this package operate on a default Meter. A Meter
+// returned by NewMeter has its own views and exporters, so a library can
+// register views and record into it (using stats.WithRecorder) without
+// affecting the views registered by the host application.
+//
 // Note that this is an advanced use case, and the static functions in this
 // module should cover the common use cases.
 type Meter interface {
@@ -77,8 +100,8 @@ type Meter interface {
 	// duration of your program execution.
 	Unregister(views ...*View)
 	// SetReportingPeriod sets the interval between reporting aggregated views in
-	// the program. If duration is less than or equal to zero, it enables the
-	// default behavior.
+	// the program. Any positive duration is honored as is. If duration is less
+	// than or equal to zero, it enables the default behavior.
 	//
 	// Note: each exporter makes different promises about what the lowest supported
 	// duration is. For example, the Stackdriver exporter recommends a value no
@@ -115,7 +138,9 @@ var _ Meter = (*worker)(nil)
 
 var defaultWorker *worker
 
-var defaultReportingDuration = 10 * time.Second
+// DefaultReportingPeriod is the interval between reports of the aggregated
+// views until SetReportingPeriod is called.
+const DefaultReportingPeriod = 10 * time.Second
 
 // Find returns a registered view associated with this name.
 // If no registered view is found, nil is returned.
@@ -135,6 +160,24 @@ func (w *worker) Find(name string) (v *View) {
 	return resp.v
 }
 
+// RegisteredViews returns the views registered with the default Meter,
+// sorted by name.
+func RegisteredViews() []*View {
+	return defaultWorker.RegisteredViews()
+}
+
+// RegisteredViews returns the registered views, sorted by name.
+func (w *worker) RegisteredViews() []*View {
+	w.mu.RLock()
+	views := make([]*View, 0, len(w.views))
+	for _, v := range w.views {
+		views = append(views, v.view)
+	}
+	w.mu.RUnlock()
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views
+}
+
 // Register begins collecting data for the given views.
 // Once a view is registered, it reports data to the registered exporters.
 func Register(views ...*View) error {
@@ -187,7 +230,7 @@ func RetrieveData(viewName string) ([]*Row, error) {
 // with the given name. It is intended for testing only.
 func (w *worker) RetrieveData(viewName string) ([]*Row, error) {
 	req := &retrieveDataReq{
-		now: time.Now(),
+		now: w.clock.Now(),
 		v:   viewName,
 		c:   make(chan *retrieveDataResp),
 	}
@@ -196,10 +239,90 @@ func (w *worker) RetrieveData(viewName string) ([]*Row, error) {
 	return resp.rows, resp.err
 }
 
+// RetrieveSnapshot returns the rows last reported to the exporters for the
+// view registered with the given name. Unlike RetrieveData, it does not wait
+// for the measurements already recorded to be aggregated, so it stays fast
+// under heavy recording, but the rows may be up to one reporting period
+// stale. It returns no rows for a view that has not been reported yet.
+//
+// The returned rows are shared with the exporters and must not be modified.
+func RetrieveSnapshot(viewName string) ([]*Row, error) {
+	return defaultWorker.RetrieveSnapshot(viewName)
+}
+
+// RetrieveSnapshot returns the rows last reported to the exporters for the
+// view registered with the given name. The rows may be up to one reporting
+// period stale and must not be modified.
+func (w *worker) RetrieveSnapshot(viewName string) ([]*Row, error) {
+	w.snapshotsMu.RLock()
+	defer w.snapshotsMu.RUnlock()
+	rows, ok := w.snapshots[viewName]
+	if !ok {
+		return nil, fmt.Errorf("cannot retrieve snapshot; view %q is not registered", viewName)
+	}
+	return rows, nil
+}
+
+// setSnapshot records the rows last reported for the view with the given
+// name.
+func (w *worker) setSnapshot(name string, rows []*Row) {
+	w.snapshotsMu.Lock()
+	w.snapshots[name] = rows
+	w.snapshotsMu.Unlock()
+}
+
+// Reset clears the data collected so far for the given registered view.
+// The view stays registered and keeps reporting to the registered exporters;
+// subsequent data only reflects measurements recorded after Reset returns.
+func Reset(v *View) error {
+	return defaultWorker.Reset(v)
+}
+
+// Reset clears the data collected so far for the given registered view.
+// The view stays registered and keeps reporting to the registered exporters;
+// subsequent data only reflects measurements recorded after Reset returns.
+func (w *worker) Reset(v *View) error {
+	req := &resetViewReq{
+		name: v.Name,
+		err:  make(chan error),
+	}
+	w.c <- req
+	return <-req.err
+}
+
+// Reregister atomically replaces the definition of the registered view with
+// the same name as v, for example to change its aggregation or tag keys.
+// Unlike calling Unregister and Register, the view stays subscribed and no
+// Record calls are lost in between.
+//
+// Data collected under the previous definition is reported to the
+// registered exporters and then reset; subsequent data only reflects
+// measurements recorded after Reregister returns. If v is the same as the
+// registered view, Reregister does nothing. An error is returned if no view
+// with that name is registered.
+func Reregister(v *View) error {
+	return defaultWorker.Reregister(v)
+}
+
+// Reregister atomically replaces the definition of the registered view with
+// the same name as v. See the package-level Reregister for details.
+func (w *worker) Reregister(v *View) error {
+	req := &reregisterViewReq{
+		view: v,
+		err:  make(chan error),
+	}
+	w.c <- req
+	return <-req.err
+}
+
 func record(tags *tag.Map, ms interface{}, attachments map[string]interface{}) {
 	defaultWorker.Record(tags, ms, attachments)
 }
 
+func recordAt(tags *tag.Map, ms interface{}, attachments map[string]interface{}, t time.Time) {
+	defaultWorker.RecordAt(tags, ms, attachments, t)
+}
+
 func recordMeasurement(tags *tag.Map, ms []stats.Measurement, attachments map[string]interface{}) {
 	defaultWorker.recordMeasurement(tags, ms, attachments)
 }
@@ -209,21 +332,62 @@ func (w *worker) Record(tags *tag.Map, ms interface{}, attachments map[string]in
 	w.recordMeasurement(tags, ms.([]stats.Measurement), attachments)
 }
 
+// RecordAt is like Record, but records the measurements at time t.
+func (w *worker) RecordAt(tags *tag.Map, ms interface{}, attachments map[string]interface{}, t time.Time) {
+	w.reportDropped(ms.([]stats.Measurement))
+	w.c <- &recordReq{
+		tm:          tags,
+		ms:          ms.([]stats.Measurement),
+		attachments: attachments,
+		t:           t,
+	}
+}
+
 // recordMeasurement records a set of measurements ms associated with the given tags and attachments.
 // This is the same as Record but without an interface{} type to avoid allocations
 func (w *worker) recordMeasurement(tags *tag.Map, ms []stats.Measurement, attachments map[string]interface{}) {
+	w.reportDropped(ms)
 	req := &recordReq{
 		tm:          tags,
 		ms:          ms,
 		attachments: attachments,
-		t:           time.Now(),
+		t:           w.clock.Now(),
 	}
 	w.c <- req
 }
 
+// reportDropped calls the function set with stats.SetOnRecordDropped, if
+// any, with the measure of each of ms that has no registered view. It runs
+// on the goroutine of the caller, after releasing w.mu, so that the function
+// may call into this package.
+func (w *worker) reportDropped(ms []stats.Measurement) {
+	if internal.OnRecordDropped == nil {
+		return
+	}
+	report := internal.OnRecordDropped()
+	if report == nil {
+		return
+	}
+	var dropped []stats.Measure
+	w.mu.RLock()
+	for _, m := range ms {
+		if (m == stats.Measurement{}) { // not registered
+			continue
+		}
+		if ref, ok := w.measures[m.Measure().Name()]; !ok || len(ref.views) == 0 {
+			dropped = append(dropped, m.Measure())
+		}
+	}
+	w.mu.RUnlock()
+	for _, m := range dropped {
+		report(m)
+	}
+}
+
 // SetReportingPeriod sets the interval between reporting aggregated views in
-// the program. If duration is less than or equal to zero, it enables the
-// default behavior.
+// the program. Any positive duration is honored as is, with no minimum. If
+// duration is less than or equal to zero, it enables the default behavior of
+// reporting every DefaultReportingPeriod.
 //
 // Note: each exporter makes different promises about what the lowest supported
 // duration is. For example, the Stackdriver exporter recommends a value no
@@ -232,21 +396,29 @@ func SetReportingPeriod(d time.Duration) {
 	defaultWorker.SetReportingPeriod(d)
 }
 
+// SetReportingPeriodForView overrides the reporting period for the view with
+// the given name, so that it is reported to the exporters on its own cadence
+// rather than the one set by SetReportingPeriod. The override applies whenever
+// a view with this name is registered. If duration is less than or equal to
+// zero, the override is removed.
+func SetReportingPeriodForView(name string, d time.Duration) {
+	defaultWorker.SetReportingPeriodForView(name, d)
+}
+
 // Stop stops the default worker.
 func Stop() {
 	defaultWorker.Stop()
 }
 
 // SetReportingPeriod sets the interval between reporting aggregated views in
-// the program. If duration is less than or equal to zero, it enables the
-// default behavior.
+// the program. Any positive duration is honored as is, with no minimum. If
+// duration is less than or equal to zero, it enables the default behavior of
+// reporting every DefaultReportingPeriod.
 //
 // Note: each exporter makes different promises about what the lowest supported
 // duration is. For example, the Stackdriver exporter recommends a value no
 // lower than 1 minute. Consult each exporter per your needs.
 func (w *worker) SetReportingPeriod(d time.Duration) {
-	// TODO(acetechnologist): ensure that the duration d is more than a certain
-	// value. e.g. 1s
 	req := &setReportingPeriodReq{
 		d: d,
 		c: make(chan bool),
@@ -255,6 +427,21 @@ func (w *worker) SetReportingPeriod(d time.Duration) {
 	<-req.c // don't return until the timer is set to the new duration.
 }
 
+// SetReportingPeriodForView overrides the reporting period for the view with
+// the given name, so that it is reported to the exporters on its own cadence
+// rather than the one set by SetReportingPeriod. The override applies whenever
+// a view with this name is registered. If duration is less than or equal to
+// zero, the override is removed.
+func (w *worker) SetReportingPeriodForView(name string, d time.Duration) {
+	req := &setViewReportingPeriodReq{
+		name: name,
+		d:    d,
+		c:    make(chan bool),
+	}
+	w.c <- req
+	<-req.c
+}
+
 // NewMeter constructs a Meter instance. You should only need to use this if
 // you need to separate out Measurement recordings and View aggregations within
 // a single process.
@@ -263,15 +450,30 @@ func NewMeter() Meter {
 		measures:       make(map[string]*measureRef),
 		views:          make(map[string]*viewInternal),
 		viewStartTimes: make(map[*viewInternal]time.Time),
-		timer:          time.NewTicker(defaultReportingDuration),
-		c:              make(chan command, 1024),
-		quit:           make(chan bool),
-		done:           make(chan bool),
+
+		reportingPeriod:      DefaultReportingPeriod,
+		viewReportingPeriods: make(map[string]time.Duration),
+		viewNextReports:      make(map[string]time.Time),
+		tickPeriod:           DefaultReportingPeriod,
+
+		clock: realClock{},
+		timer: realClock{}.NewTicker(DefaultReportingPeriod),
+		c:     make(chan command, 1024),
+		quit:  make(chan bool),
+		done:  make(chan bool),
 
 		exporters: make(map[Exporter]struct{}),
+		snapshots: make(map[string][]*Row),
 	}
 }
 
+// SetResource associates all data collected by the default Meter with the
+// specified resource, for example one returned by resource.Detect. This
+// resource is reported when using metricexport.ReadAndExport.
+func SetResource(r *resource.Resource) {
+	defaultWorker.SetResource(r)
+}
+
 // SetResource associates all data collected by this Meter with the specified
 // resource. This resource is reported when using metricexport.ReadAndExport;
 // it is not provided when used with ExportView/RegisterExporter, because that
@@ -292,8 +494,8 @@ func (w *worker) start() {
 		select {
 		case cmd := <-w.c:
 			cmd.handleCommand(w)
-		case <-w.timer.C:
-			w.reportUsage()
+		case now := <-w.timer.C():
+			w.reportUsage(now)
 		case <-w.quit:
 			w.timer.Stop()
 			close(w.c)
@@ -333,6 +535,7 @@ func (w *worker) tryRegisterView(v *View) (*viewInternal, error) {
 	if err != nil {
 		return nil, err
 	}
+	w.setDerive(vi)
 	if x, ok := w.views[vi.view.Name]; ok {
 		if !x.view.same(vi.view) {
 			return nil, fmt.Errorf("cannot register view %q; a different view with the same name is already registered", v.Name)
@@ -343,10 +546,51 @@ func (w *worker) tryRegisterView(v *View) (*viewInternal, error) {
 		return x, nil
 	}
 	w.views[vi.view.Name] = vi
-	w.viewStartTimes[vi] = time.Now()
+	w.viewStartTimes[vi] = w.clock.Now()
+	w.addMeasureRef(vi)
+	w.setSnapshot(vi.view.Name, nil)
+	return vi, nil
+}
+
+// replaceView atomically swaps old for a new view built from v, keeping the
+// subscription state of old.
+func (w *worker) replaceView(old *viewInternal, v *View) (*viewInternal, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	vi, err := newViewInternal(v)
+	if err != nil {
+		return nil, err
+	}
+	w.setDerive(vi)
+	if old.isSubscribed() {
+		vi.subscribe()
+	}
+	delete(w.viewStartTimes, old)
+	if measure := w.measures[old.view.Measure.Name()]; measure != nil {
+		delete(measure.views, old)
+	}
+	w.views[vi.view.Name] = vi
+	w.viewStartTimes[vi] = w.clock.Now()
+	w.addMeasureRef(vi)
+	return vi, nil
+}
+
+// addMeasureRef makes the measurements of the measure of vi recorded by vi,
+// unless its rows are derived from other views.
+func (w *worker) addMeasureRef(vi *viewInternal) {
+	if vi.derive != nil {
+		return
+	}
 	ref := w.getMeasureRef(vi.view.Measure.Name())
 	ref.views[vi] = struct{}{}
-	return vi, nil
+}
+
+// setDerive makes the rows of vi computed from other views if it is derived
+// from them.
+func (w *worker) setDerive(vi *viewInternal) {
+	if r := vi.view.Aggregation.ratio; r != nil {
+		vi.derive = func() []*Row { return w.ratioRows(vi.view, r) }
+	}
 }
 
 func (w *worker) unregisterView(v *viewInternal) {
@@ -354,6 +598,9 @@ func (w *worker) unregisterView(v *viewInternal) {
 	defer w.mu.Unlock()
 	delete(w.views, v.view.Name)
 	delete(w.viewStartTimes, v)
+	w.snapshotsMu.Lock()
+	delete(w.snapshots, v.view.Name)
+	w.snapshotsMu.Unlock()
 	if measure := w.measures[v.view.Measure.Name()]; measure != nil {
 		delete(measure.views, v)
 	}
@@ -364,10 +611,11 @@ func (w *worker) reportView(v *viewInternal) {
 		return
 	}
 	rows := v.collectedRows()
+	w.setSnapshot(v.view.Name, rows)
 	viewData := &Data{
 		View:  v.view,
 		Start: w.viewStartTimes[v],
-		End:   time.Now(),
+		End:   w.clock.Now(),
 		Rows:  rows,
 	}
 	w.exportersMu.Lock()
@@ -377,20 +625,62 @@ func (w *worker) reportView(v *viewInternal) {
 	}
 }
 
-func (w *worker) reportUsage() {
+// reportUsage reports the views that are due at now. A view is considered due
+// if its next report falls within half a tick, to absorb timer jitter.
+func (w *worker) reportUsage(now time.Time) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	for _, v := range w.views {
+	due := now.Add(w.tickPeriod / 2)
+	reportDefault := !due.Before(w.nextReport)
+	if reportDefault {
+		w.nextReport = now.Add(w.reportingPeriod)
+	}
+	for name, v := range w.views {
+		if d, ok := w.viewReportingPeriods[name]; ok {
+			if due.Before(w.viewNextReports[name]) {
+				continue
+			}
+			w.viewNextReports[name] = now.Add(d)
+		} else if !reportDefault {
+			continue
+		}
 		w.reportView(v)
 	}
 }
 
-func (w *worker) toMetric(v *viewInternal, now time.Time) *metricdata.Metric {
+// resetTimer restarts the timer so it ticks at the smallest reporting period
+// in use.
+func (w *worker) resetTimer() {
+	period := w.reportingPeriod
+	for _, d := range w.viewReportingPeriods {
+		if d < period {
+			period = d
+		}
+	}
+	w.timer.Stop()
+	w.timer = w.clock.NewTicker(period)
+	w.tickPeriod = period
+}
+
+// setClock replaces the clock of the worker. It is intended for tests and
+// must be called before the worker is started.
+func (w *worker) setClock(c clock) {
+	w.clock = c
+	w.timer.Stop()
+	w.timer = c.NewTicker(w.tickPeriod)
+}
+
+func (w *worker) toMetrics(v *viewInternal, now time.Time) []*metricdata.Metric {
 	if !v.isSubscribed() {
 		return nil
 	}
-
-	return viewToMetric(v, w.r, now)
+	if v.view.Aggregation.Type == AggTypeMinMax {
+		return minMaxToMetrics(v, w.r, now)
+	}
+	if metric := viewToMetric(v, w.r, now); metric != nil {
+		return []*metricdata.Metric{metric}
+	}
+	return nil
 }
 
 // Read reads all view data and returns them as metrics.
@@ -398,13 +688,10 @@ func (w *worker) toMetric(v *viewInternal, now time.Time) *metricdata.Metric {
 func (w *worker) Read() []*metricdata.Metric {
 	w.mu.Lock()
 	defer w.mu.Unlock()
-	now := time.Now()
+	now := w.clock.Now()
 	metrics := make([]*metricdata.Metric, 0, len(w.views))
 	for _, v := range w.views {
-		metric := w.toMetric(v, now)
-		if metric != nil {
-			metrics = append(metrics, metric)
-		}
+		metrics = append(metrics, w.toMetrics(v, now)...)
 	}
 	return metrics
 }
@@ -416,6 +703,39 @@ func (w *worker) RegisterExporter(e Exporter) {
 	w.exporters[e] = struct{}{}
 }
 
+// Shutdown reports the data collected for all views a last time,
+// unregisters all exporters and flushes those implementing Flusher. See the
+// package-level Shutdown for details.
+func (w *worker) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		req := &reportAllReq{c: make(chan struct{})}
+		w.c <- req
+		<-req.c
+
+		w.exportersMu.Lock()
+		exporters := w.exporters
+		w.exporters = make(map[Exporter]struct{})
+		w.exportersMu.Unlock()
+
+		var err error
+		for e := range exporters {
+			if f, ok := e.(Flusher); ok {
+				if ferr := f.Flush(ctx); ferr != nil && err == nil {
+					err = ferr
+				}
+			}
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (w *worker) UnregisterExporter(e Exporter) {
 	w.exportersMu.Lock()
 	defer w.exportersMu.Unlock()
@@ -18,6 +18,7 @@ package view
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -69,7 +70,9 @@ func (cmd *registerViewReq) handleCommand(w *worker) {
 			errstr = append(errstr, fmt.Sprintf("%s: %v", view.Name, err))
 			continue
 		}
-		internal.SubscriptionReporter(view.Measure.Name())
+		if vi.derive == nil {
+			internal.SubscriptionReporter(view.Measure.Name())
+		}
 		vi.subscribe()
 	}
 	if len(errstr) > 0 {
@@ -145,6 +148,74 @@ func (cmd *retrieveDataReq) handleCommand(w *worker) {
 	}
 }
 
+// resetViewReq is the command to clear the data collected for a view.
+type resetViewReq struct {
+	name string
+	err  chan error
+}
+
+func (cmd *resetViewReq) handleCommand(w *worker) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	vi, ok := w.views[cmd.name]
+	if !ok {
+		cmd.err <- fmt.Errorf("cannot reset view %q: view is not registered", cmd.name)
+		return
+	}
+	vi.clearRows()
+	w.viewStartTimes[vi] = w.clock.Now()
+	cmd.err <- nil
+}
+
+// reregisterViewReq is the command to replace the definition of a
+// registered view.
+type reregisterViewReq struct {
+	view *View
+	err  chan error
+}
+
+func (cmd *reregisterViewReq) handleCommand(w *worker) {
+	if err := cmd.view.canonicalize(); err != nil {
+		cmd.err <- err
+		return
+	}
+	old, ok := w.views[cmd.view.Name]
+	if !ok {
+		cmd.err <- fmt.Errorf("cannot reregister view %q: view is not registered", cmd.view.Name)
+		return
+	}
+	if old.view.same(cmd.view) {
+		cmd.err <- nil
+		return
+	}
+
+	// Report pending data under the old definition before replacing it.
+	w.reportView(old)
+
+	vi, err := w.replaceView(old, cmd.view)
+	if err != nil {
+		cmd.err <- err
+		return
+	}
+	if vi.isSubscribed() && vi.derive == nil {
+		internal.SubscriptionReporter(vi.view.Measure.Name())
+	}
+	cmd.err <- nil
+}
+
+// reportAllReq is the command to report the data collected for all views to
+// the registered exporters.
+type reportAllReq struct {
+	c chan struct{}
+}
+
+func (cmd *reportAllReq) handleCommand(w *worker) {
+	for _, v := range w.views {
+		w.reportView(v)
+	}
+	cmd.c <- struct{}{}
+}
+
 // recordReq is the command to record data related to multiple measures
 // at once.
 type recordReq struct {
@@ -161,9 +232,18 @@ func (cmd *recordReq) handleCommand(w *worker) {
 		if (m == stats.Measurement{}) { // not registered
 			continue
 		}
+		if v := m.Value(); math.IsNaN(v) || math.IsInf(v, 0) {
+			// Would corrupt the sum and mean of aggregations for good.
+			continue
+		}
 		ref := w.getMeasureRef(m.Measure().Name())
+		iv, isInt := m.Int64Value()
 		for v := range ref.views {
-			v.addSample(cmd.tm, m.Value(), cmd.attachments, cmd.t)
+			if isInt {
+				v.addInt64Sample(cmd.tm, iv, cmd.attachments, cmd.t)
+			} else {
+				v.addSample(cmd.tm, m.Value(), cmd.attachments, cmd.t)
+			}
 		}
 	}
 }
@@ -176,11 +256,31 @@ type setReportingPeriodReq struct {
 }
 
 func (cmd *setReportingPeriodReq) handleCommand(w *worker) {
-	w.timer.Stop()
+	w.reportingPeriod = cmd.d
+	if w.reportingPeriod <= 0 {
+		w.reportingPeriod = DefaultReportingPeriod
+	}
+	w.nextReport = w.clock.Now().Add(w.reportingPeriod)
+	w.resetTimer()
+	cmd.c <- true
+}
+
+// setViewReportingPeriodReq is the command to override the duration between
+// reporting the collected data of a single view.
+type setViewReportingPeriodReq struct {
+	name string
+	d    time.Duration
+	c    chan bool
+}
+
+func (cmd *setViewReportingPeriodReq) handleCommand(w *worker) {
 	if cmd.d <= 0 {
-		w.timer = time.NewTicker(defaultReportingDuration)
+		delete(w.viewReportingPeriods, cmd.name)
+		delete(w.viewNextReports, cmd.name)
 	} else {
-		w.timer = time.NewTicker(cmd.d)
+		w.viewReportingPeriods[cmd.name] = cmd.d
+		w.viewNextReports[cmd.name] = w.clock.Now().Add(cmd.d)
 	}
+	w.resetTimer()
 	cmd.c <- true
 }
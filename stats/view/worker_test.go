@@ -18,6 +18,7 @@ package view
 import (
 	"context"
 	"errors"
+	"math"
 	"sort"
 	"sync"
 	"testing"
@@ -137,8 +138,8 @@ func Test_Worker_MultiExport(t *testing.T) {
 
 	m := stats.Float64("Test_Worker_MultiExport/MF1", "desc MF1", "unit")
 	key := tag.MustNewKey(("key"))
-	count := &View{"VF1", "description", []tag.Key{key}, m, Count()}
-	sum := &View{"VF2", "description", []tag.Key{}, m, Sum()}
+	count := &View{Name: "VF1", Description: "description", TagKeys: []tag.Key{key}, Measure: m, Aggregation: Count()}
+	sum := &View{Name: "VF2", Description: "description", TagKeys: []tag.Key{}, Measure: m, Aggregation: Sum()}
 
 	Register(count, sum)
 	worker2.Register(count) // Don't compute the sum for worker2, to verify independence of computation.
@@ -254,8 +255,8 @@ func Test_Worker_RecordFloat64(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	v1 := &View{"VF1", "desc VF1", []tag.Key{k1, k2}, m, Count()}
-	v2 := &View{"VF2", "desc VF2", []tag.Key{k1, k2}, m, Count()}
+	v1 := &View{Name: "VF1", Description: "desc VF1", TagKeys: []tag.Key{k1, k2}, Measure: m, Aggregation: Count()}
+	v2 := &View{Name: "VF2", Description: "desc VF2", TagKeys: []tag.Key{k1, k2}, Measure: m, Aggregation: Count()}
 
 	type want struct {
 		v    *View
@@ -521,6 +522,187 @@ func TestUnregisterReportsUsage(t *testing.T) {
 	}
 }
 
+func TestReset(t *testing.T) {
+	restart()
+	ctx := context.Background()
+
+	m := stats.Int64("measure", "desc", "unit")
+	v := &View{Name: "count", Measure: m, Aggregation: Count()}
+	if err := Register(v); err != nil {
+		t.Fatalf("cannot register: %v", err)
+	}
+
+	stats.Record(ctx, m.M(1))
+	stats.Record(ctx, m.M(1))
+	if err := Reset(v); err != nil {
+		t.Fatalf("Reset() = %v", err)
+	}
+	rows, err := RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows after reset; want none", len(rows))
+	}
+
+	stats.Record(ctx, m.M(1))
+	rows, err = RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	if got, want := rows[0].Data.(*CountData).Value, int64(1); got != want {
+		t.Errorf("got count = %v; want %v", got, want)
+	}
+
+	if err := Reset(&View{Name: "unregistered"}); err == nil {
+		t.Error("Reset() of an unregistered view = nil; want error")
+	}
+}
+
+func TestRecordNaNAndInf(t *testing.T) {
+	restart()
+	ctx := context.Background()
+
+	m := stats.Float64("measure", "desc", "unit")
+	v := &View{Name: "dist", Measure: m, Aggregation: Distribution(2)}
+	if err := Register(v); err != nil {
+		t.Fatalf("cannot register: %v", err)
+	}
+	for _, f := range []float64{1, math.NaN(), math.Inf(1), math.Inf(-1), 3} {
+		stats.Record(ctx, m.M(f))
+	}
+	rows, err := RetrieveData(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	d := rows[0].Data.(*DistributionData)
+	if d.Count != 2 || d.Mean != 2 || d.Min != 1 || d.Max != 3 || d.SumOfSquaredDev != 2 {
+		t.Errorf("got count=%d mean=%v min=%v max=%v ssd=%v; want 2, 2, 1, 3, 2",
+			d.Count, d.Mean, d.Min, d.Max, d.SumOfSquaredDev)
+	}
+}
+
+type flushExporter struct {
+	vdExporter
+	flushed bool
+}
+
+func (e *flushExporter) Flush(ctx context.Context) error {
+	e.flushed = true
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	restart()
+
+	m := stats.Int64("measure", "desc", "unit")
+	v := &View{Name: "count", Measure: m, Aggregation: Count()}
+	if err := Register(v); err != nil {
+		t.Fatalf("cannot register: %v", err)
+	}
+	e := &flushExporter{}
+	RegisterExporter(e)
+	stats.Record(context.Background(), m.M(1))
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() = %v", err)
+	}
+	if !e.flushed {
+		t.Error("Flush was not called")
+	}
+	e.Lock()
+	defer e.Unlock()
+	if len(e.vds) != 1 || len(e.vds[0].Rows) != 1 {
+		t.Fatalf("got reports %v; want one report of the recorded data", e.vds)
+	}
+	if got := e.vds[0].Rows[0].Data.(*CountData).Value; got != 1 {
+		t.Errorf("reported count = %d; want 1", got)
+	}
+}
+
+func TestReregister(t *testing.T) {
+	restart()
+	ctx := context.Background()
+
+	m := stats.Int64("measure", "desc", "unit")
+	if err := Register(&View{Name: "v", Measure: m, Aggregation: Count()}); err != nil {
+		t.Fatalf("cannot register: %v", err)
+	}
+	stats.Record(ctx, m.M(5))
+
+	if err := Reregister(&View{Name: "v", Measure: m, Aggregation: Sum()}); err != nil {
+		t.Fatalf("Reregister() = %v", err)
+	}
+	if got := Find("v").Aggregation.Type; got != AggTypeSum {
+		t.Errorf("aggregation after Reregister = %v; want %v", got, AggTypeSum)
+	}
+	rows, err := RetrieveData("v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows after Reregister; want none", len(rows))
+	}
+
+	stats.Record(ctx, m.M(5))
+	stats.Record(ctx, m.M(7))
+	rows, err = RetrieveData("v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows; want 1", len(rows))
+	}
+	if got, want := rows[0].Data.(*SumData).Value, 12.0; got != want {
+		t.Errorf("got sum = %v; want %v", got, want)
+	}
+
+	if err := Reregister(&View{Name: "unregistered", Measure: m, Aggregation: Sum()}); err == nil {
+		t.Error("Reregister() of an unregistered view = nil; want error")
+	}
+}
+
+func TestSetReportingPeriodForView(t *testing.T) {
+	restart()
+
+	m := stats.Int64("measure", "desc", "unit")
+	fast := &View{Name: "fast", Measure: m, Aggregation: Count()}
+	slow := &View{Name: "slow", Measure: m, Aggregation: Count()}
+
+	SetReportingPeriod(time.Hour)
+	SetReportingPeriodForView(fast.Name, 20*time.Millisecond)
+	SetReportingPeriodForView(slow.Name, 200*time.Millisecond)
+	if err := Register(fast, slow); err != nil {
+		t.Fatalf("cannot register: %v", err)
+	}
+
+	e := &vdExporter{}
+	RegisterExporter(e)
+	defer UnregisterExporter(e)
+
+	time.Sleep(500 * time.Millisecond)
+
+	e.Lock()
+	counts := make(map[string]int)
+	for _, vd := range e.vds {
+		counts[vd.View.Name]++
+	}
+	e.Unlock()
+
+	if counts[slow.Name] == 0 {
+		t.Errorf("slow view was never exported")
+	}
+	if counts[fast.Name] <= counts[slow.Name] {
+		t.Errorf("fast view exported %d times, slow view %d times; want fast > slow", counts[fast.Name], counts[slow.Name])
+	}
+}
+
 func TestWorkerRace(t *testing.T) {
 	restart()
 	ctx := context.Background()
@@ -661,3 +843,252 @@ func (ts byLabel) Less(i, j int) bool {
 	}
 	return false
 }
+
+// fakeClock is a clock whose time only moves when advanced.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+type fakeTicker struct {
+	clock   *fakeClock
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	t.stopped = true
+	t.clock.mu.Unlock()
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Ticks are unbuffered so that advance returns once the worker has
+	// received them.
+	t := &fakeTicker{clock: c, c: make(chan time.Time), period: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// advance moves the clock forward by d and delivers the ticks that became
+// due to the running tickers.
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+	var due []*fakeTicker
+	for _, t := range c.tickers {
+		for !t.stopped && !t.next.After(now) {
+			due = append(due, t)
+			t.next = t.next.Add(t.period)
+		}
+	}
+	c.mu.Unlock()
+	for _, t := range due {
+		t.c <- now
+	}
+}
+
+func TestWorkerFakeClock(t *testing.T) {
+	fc := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	w := NewMeter().(*worker)
+	w.setClock(fc)
+	w.Start()
+	defer w.Stop()
+
+	m := stats.Int64("measure", "desc", "unit")
+	v := &View{Name: "count", Measure: m, Aggregation: Count()}
+	w.SetReportingPeriod(10 * time.Second)
+	if err := w.Register(v); err != nil {
+		t.Fatalf("cannot register: %v", err)
+	}
+	e := &vdExporter{}
+	w.RegisterExporter(e)
+	stats.RecordWithOptions(context.Background(), stats.WithRecorder(w), stats.WithMeasurements(m.M(1)))
+
+	reports := func() []*Data {
+		// Find is handled after any tick delivered before it, so the
+		// reports of those ticks are done once it returns.
+		w.Find(v.Name)
+		e.Lock()
+		defer e.Unlock()
+		return append([]*Data(nil), e.vds...)
+	}
+
+	fc.advance(9 * time.Second)
+	if got := len(reports()); got != 0 {
+		t.Fatalf("got %d reports before the reporting period elapsed; want 0", got)
+	}
+	fc.advance(time.Second)
+	vds := reports()
+	if len(vds) != 1 {
+		t.Fatalf("got %d reports after the reporting period; want 1", len(vds))
+	}
+	if got, want := vds[0].End, fc.Now(); !got.Equal(want) {
+		t.Errorf("report End = %v; want %v", got, want)
+	}
+	if got, want := vds[0].Start, fc.Now().Add(-10*time.Second); !got.Equal(want) {
+		t.Errorf("report Start = %v; want %v", got, want)
+	}
+	fc.advance(5 * time.Second)
+	if got := len(reports()); got != 1 {
+		t.Errorf("got %d reports halfway through the next period; want 1", got)
+	}
+}
+
+func TestRetrieveSnapshot(t *testing.T) {
+	fc := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	w := NewMeter().(*worker)
+	w.setClock(fc)
+	w.Start()
+	defer w.Stop()
+
+	m := stats.Int64("TestRetrieveSnapshot", "", stats.UnitDimensionless)
+	v := &View{Name: "TestRetrieveSnapshot", Measure: m, Aggregation: Count()}
+	w.SetReportingPeriod(10 * time.Second)
+	if _, err := w.RetrieveSnapshot(v.Name); err == nil {
+		t.Error("RetrieveSnapshot() of an unregistered view = nil error; want an error")
+	}
+	if err := w.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	stats.RecordWithOptions(context.Background(), stats.WithRecorder(w), stats.WithMeasurements(m.M(1)))
+
+	rows, err := w.RetrieveSnapshot(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows before the view was reported; want 0", len(rows))
+	}
+
+	fc.advance(10 * time.Second)
+	w.Find(v.Name) // wait for the report
+	rows, err = w.RetrieveSnapshot(v.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Data.(*CountData).Value != 1 {
+		t.Errorf("got rows %v after the view was reported; want a count of 1", rows)
+	}
+
+	w.Unregister(v)
+	if _, err := w.RetrieveSnapshot(v.Name); err == nil {
+		t.Error("RetrieveSnapshot() of an unregistered view = nil error; want an error")
+	}
+}
+
+func TestInt64SumIsExact(t *testing.T) {
+	w := NewMeter().(*worker)
+	w.Start()
+	defer w.Stop()
+
+	m := stats.Int64("TestInt64SumIsExact", "", stats.UnitDimensionless)
+	v := &View{Name: "TestInt64SumIsExact", Measure: m, Aggregation: Sum()}
+	if err := w.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	const big = 1<<53 + 1 // not representable as a float64
+	ctx := context.Background()
+	stats.RecordWithOptions(ctx, stats.WithRecorder(w), stats.WithMeasurements(m.M(big)))
+	stats.RecordWithOptions(ctx, stats.WithRecorder(w), stats.WithMeasurements(m.M(2)))
+	w.Find(v.Name) // wait for the measurements to be aggregated
+
+	metrics := w.Read()
+	if len(metrics) != 1 || len(metrics[0].TimeSeries) != 1 {
+		t.Fatalf("got metrics %v; want a single time series", metrics)
+	}
+	if got, want := metrics[0].TimeSeries[0].Points[0].Value, int64(big+2); got != want {
+		t.Errorf("sum = %v; want %v", got, want)
+	}
+
+	// Rows still hold a plain SumData.
+	rows, err := w.RetrieveData(v.Name)
+	if err != nil || len(rows) != 1 {
+		t.Fatalf("RetrieveData() = %v, %v; want a single row", rows, err)
+	}
+	ClearStart(rows[0].Data)
+	if diff := cmp.Diff(rows[0].Data, &SumData{Value: float64(big + 2)}); diff != "" {
+		t.Errorf("row data -got +want: %s", diff)
+	}
+}
+
+func TestRegisteredViews(t *testing.T) {
+	w := NewMeter().(*worker)
+	w.Start()
+	defer w.Stop()
+
+	m := stats.Int64("TestRegisteredViews", "", stats.UnitDimensionless)
+	b := &View{Name: "TestRegisteredViews/b", Measure: m, Aggregation: Count()}
+	a := &View{Name: "TestRegisteredViews/a", Measure: m, Aggregation: Sum()}
+	if got := w.RegisteredViews(); len(got) != 0 {
+		t.Errorf("RegisteredViews() = %v; want none", got)
+	}
+	if err := w.Register(b, a); err != nil {
+		t.Fatal(err)
+	}
+	if got := w.RegisteredViews(); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("RegisteredViews() = %v; want [%v %v]", got, a, b)
+	}
+	w.Unregister(a)
+	if got := w.RegisteredViews(); len(got) != 1 || got[0] != b {
+		t.Errorf("RegisteredViews() after Unregister = %v; want [%v]", got, b)
+	}
+}
+
+func TestSetReportingPeriodExact(t *testing.T) {
+	fc := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	w := NewMeter().(*worker)
+	w.setClock(fc)
+	w.Start()
+	defer w.Stop()
+
+	for _, d := range []time.Duration{0, -time.Second} {
+		w.SetReportingPeriod(time.Minute)
+		w.SetReportingPeriod(d)
+		if got := w.reportingPeriod; got != DefaultReportingPeriod {
+			t.Errorf("SetReportingPeriod(%v) set the period to %v; want %v", d, got, DefaultReportingPeriod)
+		}
+	}
+	w.SetReportingPeriod(5 * time.Second)
+
+	m := stats.Int64("TestSetReportingPeriodExact", "", stats.UnitDimensionless)
+	v := &View{Name: "TestSetReportingPeriodExact", Measure: m, Aggregation: Count()}
+	if err := w.Register(v); err != nil {
+		t.Fatal(err)
+	}
+	e := &vdExporter{}
+	w.RegisterExporter(e)
+	reports := func() int {
+		w.Find(v.Name) // wait for the reports of the ticks delivered
+		e.Lock()
+		defer e.Unlock()
+		return len(e.vds)
+	}
+
+	fc.advance(4 * time.Second)
+	if got := reports(); got != 0 {
+		t.Fatalf("got %d reports after 4s; want 0", got)
+	}
+	fc.advance(time.Second)
+	if got := reports(); got != 1 {
+		t.Fatalf("got %d reports after 5s; want 1", got)
+	}
+	fc.advance(5 * time.Second)
+	if got := reports(); got != 2 {
+		t.Errorf("got %d reports after 10s; want 2", got)
+	}
+}
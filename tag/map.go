@@ -52,21 +52,68 @@ func (m *Map) String() string {
 	if m == nil {
 		return "nil"
 	}
-	keys := make([]Key, 0, len(m.m))
-	for k := range m.m {
-		keys = append(keys, k)
-	}
-	sort.Slice(keys, func(i, j int) bool { return keys[i].Name() < keys[j].Name() })
-
 	var buffer bytes.Buffer
 	buffer.WriteString("{ ")
-	for _, k := range keys {
+	for _, k := range m.sortedKeys() {
 		buffer.WriteString(fmt.Sprintf("{%v %v}", k.name, m.m[k]))
 	}
 	buffer.WriteString(" }")
 	return buffer.String()
 }
 
+// Entries returns the tags of the map sorted by key name.
+func (m *Map) Entries() []Tag {
+	if m == nil {
+		return nil
+	}
+	tags := make([]Tag, 0, len(m.m))
+	for _, k := range m.sortedKeys() {
+		tags = append(tags, Tag{Key: k, Value: m.m[k].value})
+	}
+	return tags
+}
+
+// Subset returns a new map holding the tags of m whose key is one of keys,
+// with their metadata. Keys not in m are ignored. m is not modified.
+func (m *Map) Subset(keys ...Key) *Map {
+	if m == nil {
+		return nil
+	}
+	sub := newMap()
+	for _, k := range keys {
+		if v, ok := m.m[k]; ok {
+			sub.m[k] = v
+		}
+	}
+	return sub
+}
+
+// Delete returns a new map holding the tags of m except those whose key is
+// one of keys, with their metadata. m is not modified.
+func (m *Map) Delete(keys ...Key) *Map {
+	if m == nil {
+		return nil
+	}
+	rest := newMap()
+	for k, v := range m.m {
+		rest.m[k] = v
+	}
+	for _, k := range keys {
+		delete(rest.m, k)
+	}
+	return rest
+}
+
+// sortedKeys returns the keys of the map sorted by name.
+func (m *Map) sortedKeys() []Key {
+	keys := make([]Key, 0, len(m.m))
+	for k := range m.m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name() < keys[j].Name() })
+	return keys
+}
+
 func (m *Map) insert(k Key, v string, md metadatas) {
 	if _, ok := m.m[k]; ok {
 		return
@@ -185,15 +232,27 @@ func Delete(k Key) Mutator {
 // originated from the incoming context and modified
 // with the provided mutators.
 func New(ctx context.Context, mutator ...Mutator) (context.Context, error) {
+	m, err := NewMap(ctx, mutator...)
+	if err != nil {
+		return ctx, err
+	}
+	return NewContext(ctx, m), nil
+}
+
+// NewMap returns a new tag map originated from the tag map
+// in the incoming context and modified with the provided mutators.
+// Unlike New, it does not create a new context, which is useful
+// when the tags are only needed for a single recording.
+func NewMap(ctx context.Context, mutator ...Mutator) (*Map, error) {
 	m := newMap()
 	orig := FromContext(ctx)
 	if orig != nil {
 		for k, v := range orig.m {
 			if !checkKeyName(k.Name()) {
-				return ctx, fmt.Errorf("key:%q: %v", k, errInvalidKeyName)
+				return nil, fmt.Errorf("key:%q: %v", k, errInvalidKeyName)
 			}
 			if !checkValue(v.value) {
-				return ctx, fmt.Errorf("key:%q value:%q: %v", k.Name(), v, errInvalidValue)
+				return nil, fmt.Errorf("key:%q value:%q: %v", k.Name(), v, errInvalidValue)
 			}
 			m.insert(k, v.value, v.m)
 		}
@@ -202,10 +261,10 @@ func New(ctx context.Context, mutator ...Mutator) (context.Context, error) {
 	for _, mod := range mutator {
 		m, err = mod.Mutate(m)
 		if err != nil {
-			return ctx, err
+			return nil, err
 		}
 	}
-	return NewContext(ctx, m), nil
+	return m, nil
 }
 
 // Do is similar to pprof.Do: a convenience for installing the tags
@@ -0,0 +1,91 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package tag
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// EncodeBaggage encodes the tag map in the W3C baggage format
+// (https://www.w3.org/TR/baggage/), as a comma separated list of
+// key=value members with the keys and values percent-encoded.
+// Tags with TTLNoPropagation are not encoded.
+func EncodeBaggage(m *Map) string {
+	if m == nil {
+		return ""
+	}
+	members := make([]string, 0, len(m.m))
+	for k, v := range m.m {
+		if v.m.ttl.ttl == valueTTLUnlimitedPropagation {
+			members = append(members, escapeBaggage(k.name)+"="+escapeBaggage(v.value))
+		}
+	}
+	sort.Strings(members)
+	return strings.Join(members, ",")
+}
+
+// DecodeBaggage decodes a tag map from the W3C baggage format. Member
+// properties are ignored. Members whose decoded key or value is not a valid
+// tag key or value, such as values containing non-ASCII characters, are
+// skipped. An error is returned if a member is malformed.
+func DecodeBaggage(s string) (*Map, error) {
+	m := newMap()
+	for _, member := range strings.Split(s, ",") {
+		if i := strings.IndexByte(member, ';'); i >= 0 {
+			member = member[:i]
+		}
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		i := strings.IndexByte(member, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("cannot decode baggage: member %q has no value", member)
+		}
+		k, err := url.PathUnescape(strings.TrimSpace(member[:i]))
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode baggage: %v", err)
+		}
+		v, err := url.PathUnescape(strings.TrimSpace(member[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode baggage: %v", err)
+		}
+		if !checkKeyName(k) || !checkValue(v) {
+			continue
+		}
+		m.upsert(Key{name: k}, v, createMetadatas(WithTTL(TTLUnlimitedPropagation)))
+	}
+	return m, nil
+}
+
+// escapeBaggage percent-encodes every byte of s other than the unreserved
+// characters of RFC 3986.
+func escapeBaggage(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
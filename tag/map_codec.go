@@ -160,7 +160,9 @@ func (eg *encoderGRPC) bytes() []byte {
 }
 
 // Encode encodes the tag map into a []byte. It is useful to propagate
-// the tag maps on wire in binary format.
+// the tag maps on wire in binary format. Tags are encoded in the order of
+// their key names, so maps holding the same tags are encoded to the same
+// bytes.
 func Encode(m *Map) []byte {
 	if m == nil {
 		return nil
@@ -169,8 +171,8 @@ func Encode(m *Map) []byte {
 		buf: make([]byte, len(m.m)),
 	}
 	eg.writeByte(tagsVersionID)
-	for k, v := range m.m {
-		if v.m.ttl.ttl == valueTTLUnlimitedPropagation {
+	for _, k := range m.sortedKeys() {
+		if v := m.m[k]; v.m.ttl.ttl == valueTTLUnlimitedPropagation {
 			eg.writeByte(byte(keyTypeString))
 			eg.writeStringWithVarintLen(k.name)
 			eg.writeBytesWithVarintLen([]byte(v.value))
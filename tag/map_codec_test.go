@@ -16,6 +16,7 @@
 package tag
 
 import (
+	"bytes"
 	"context"
 	"reflect"
 	"sort"
@@ -104,6 +105,67 @@ func TestEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestEncodeSkipsNoPropagationTags(t *testing.T) {
+	k1, _ := NewKey("k1")
+	k2, _ := NewKey("k2")
+	ctx, err := New(context.Background(),
+		Insert(k1, "v1", WithTTL(TTLUnlimitedPropagation)),
+		Insert(k2, "v2", WithTTL(TTLNoPropagation)),
+	)
+	if err != nil {
+		t.Fatalf("New = %v", err)
+	}
+
+	decoded, err := Decode(Encode(FromContext(ctx)))
+	if err != nil {
+		t.Fatalf("decoding encoded tag map failed: %v", err)
+	}
+	if v, ok := decoded.Value(k1); !ok || v != "v1" {
+		t.Errorf("decoded.Value(k1) = %q, %v; want %q, true", v, ok, "v1")
+	}
+	if v, ok := decoded.Value(k2); ok {
+		t.Errorf("decoded.Value(k2) = %q, true; want no-propagation tag to be dropped", v)
+	}
+}
+
+func TestEncodeIsDeterministic(t *testing.T) {
+	var keys []Key
+	for _, name := range []string{"k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8"} {
+		k, _ := NewKey(name)
+		keys = append(keys, k)
+	}
+	build := func(order []int) *Map {
+		var mods []Mutator
+		for _, i := range order {
+			mods = append(mods, Insert(keys[i], "v"+keys[i].Name()))
+		}
+		m, err := NewMap(context.Background(), mods...)
+		if err != nil {
+			t.Fatalf("NewMap = %v", err)
+		}
+		return m
+	}
+	m1 := build([]int{0, 1, 2, 3, 4, 5, 6, 7})
+	m2 := build([]int{7, 3, 5, 1, 0, 6, 2, 4})
+
+	want := Encode(m1)
+	for i := 0; i < 10; i++ {
+		if got := Encode(m2); !bytes.Equal(got, want) {
+			t.Fatalf("Encode() = %v; want %v", got, want)
+		}
+	}
+
+	entries := m2.Entries()
+	if len(entries) != len(keys) {
+		t.Fatalf("got %d entries; want %d", len(entries), len(keys))
+	}
+	for i, tag := range entries {
+		if tag.Key != keys[i] || tag.Value != "v"+keys[i].Name() {
+			t.Errorf("entries[%d] = %v; want {%v v%v}", i, tag, keys[i], keys[i].Name())
+		}
+	}
+}
+
 func TestDecode(t *testing.T) {
 	k1, _ := NewKey("k1")
 	ctx, _ := New(context.Background(), Insert(k1, "v1"))
@@ -47,6 +47,50 @@ func TestContext(t *testing.T) {
 	}
 }
 
+func TestSubsetAndDelete(t *testing.T) {
+	k1, _ := NewKey("k1")
+	k2, _ := NewKey("k2")
+	k3, _ := NewKey("k3")
+	missing, _ := NewKey("missing")
+
+	m, err := NewMap(context.Background(),
+		Insert(k1, "v1"),
+		Insert(k2, "v2", WithTTL(TTLNoPropagation)),
+		Insert(k3, "v3"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := newMap()
+	want.insert(k1, "v1", ttlUnlimitedPropMd)
+	want.insert(k2, "v2", ttlNoPropMd)
+	if got := m.Subset(k1, k2, missing); !reflect.DeepEqual(got, want) {
+		t.Errorf("Subset(k1, k2, missing) = %v; want %v", got, want)
+	}
+
+	want = newMap()
+	want.insert(k3, "v3", ttlUnlimitedPropMd)
+	if got := m.Delete(k1, k2, missing); !reflect.DeepEqual(got, want) {
+		t.Errorf("Delete(k1, k2, missing) = %v; want %v", got, want)
+	}
+
+	if got := m.Subset(); len(got.m) != 0 {
+		t.Errorf("Subset() = %v; want an empty map", got)
+	}
+	if got := m.Delete(); !reflect.DeepEqual(got, m) || got == m {
+		t.Errorf("Delete() = %v; want a copy of %v", got, m)
+	}
+	// m is left unchanged.
+	if len(m.m) != 3 {
+		t.Errorf("m = %v; want its 3 tags", m)
+	}
+	var nilMap *Map
+	if nilMap.Subset(k1) != nil || nilMap.Delete(k1) != nil {
+		t.Error("Subset or Delete of a nil map is not nil")
+	}
+}
+
 func TestDo(t *testing.T) {
 	k1, _ := NewKey("k1")
 	k2, _ := NewKey("k2")
@@ -162,7 +206,16 @@ func TestNewMap(t *testing.T) {
 		}
 		mods = append(mods, tt.mods...)
 		ctx := NewContext(context.Background(), tt.initial)
-		ctx, err := New(ctx, mods...)
+
+		m, err := NewMap(ctx, mods...)
+		if tt.want != nil && err != nil {
+			t.Errorf("%v: NewMap = %v", tt.name, err)
+		}
+		if got, want := m, tt.want; !reflect.DeepEqual(got, want) {
+			t.Errorf("%v: NewMap got %v; want %v", tt.name, got, want)
+		}
+
+		ctx, err = New(ctx, mods...)
 		if tt.want != nil && err != nil {
 			t.Errorf("%v: New = %v", tt.name, err)
 		}
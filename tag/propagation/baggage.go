@@ -0,0 +1,50 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package propagation implements propagation of tags in the W3C baggage
+// HTTP header (https://www.w3.org/TR/baggage/).
+package propagation // import "go.opencensus.io/tag/propagation"
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opencensus.io/tag"
+)
+
+// BaggageHeader is the name of the W3C baggage HTTP header.
+const BaggageHeader = "baggage"
+
+// Inject sets the baggage header in h to the tags in the context.
+// Tags with tag.TTLNoPropagation are not propagated.
+func Inject(ctx context.Context, h http.Header) {
+	if s := tag.EncodeBaggage(tag.FromContext(ctx)); s != "" {
+		h.Set(BaggageHeader, s)
+	}
+}
+
+// Extract returns a copy of ctx holding the tags decoded from the baggage
+// headers in h. If h has no baggage header, ctx is returned unchanged.
+func Extract(ctx context.Context, h http.Header) (context.Context, error) {
+	values := h[http.CanonicalHeaderKey(BaggageHeader)]
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	m, err := tag.DecodeBaggage(strings.Join(values, ","))
+	if err != nil {
+		return ctx, err
+	}
+	return tag.NewContext(ctx, m), nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opencensus.io/tag"
+)
+
+func TestInjectExtract(t *testing.T) {
+	k1 := tag.MustNewKey("k1")
+	k2 := tag.MustNewKey("key=with,reserved")
+	local := tag.MustNewKey("local")
+	ctx, err := tag.New(context.Background(),
+		tag.Insert(k1, "a,b=c d%"),
+		tag.Insert(k2, "v2"),
+		tag.Insert(local, "pod", tag.WithTTL(tag.TTLNoPropagation)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := http.Header{}
+	Inject(ctx, h)
+	if got, want := h.Get(BaggageHeader), "k1=a%2Cb%3Dc%20d%25,key%3Dwith%2Creserved=v2"; got != want {
+		t.Errorf("baggage header = %q; want %q", got, want)
+	}
+
+	ctx, err = Extract(context.Background(), h)
+	if err != nil {
+		t.Fatalf("Extract() = %v", err)
+	}
+	m := tag.FromContext(ctx)
+	if v, _ := m.Value(k1); v != "a,b=c d%" {
+		t.Errorf("k1 = %q; want %q", v, "a,b=c d%")
+	}
+	if v, _ := m.Value(k2); v != "v2" {
+		t.Errorf("k2 = %q; want %q", v, "v2")
+	}
+	if _, ok := m.Value(local); ok {
+		t.Error("tag with TTLNoPropagation was propagated")
+	}
+}
+
+func TestExtract(t *testing.T) {
+	k1 := tag.MustNewKey("k1")
+	k2 := tag.MustNewKey("k2")
+	tests := []struct {
+		name    string
+		header  []string
+		want    map[tag.Key]string
+		wantErr bool
+	}{
+		{
+			name:   "whitespace and properties",
+			header: []string{" k1 = v1 ;prop=1 , k2=v2"},
+			want:   map[tag.Key]string{k1: "v1", k2: "v2"},
+		},
+		{
+			name:   "multiple headers",
+			header: []string{"k1=v1", "k2=v2"},
+			want:   map[tag.Key]string{k1: "v1", k2: "v2"},
+		},
+		{
+			name:   "non-ASCII value is skipped",
+			header: []string{"k1=%C3%A9t%C3%A9,k2=v2"},
+			want:   map[tag.Key]string{k2: "v2"},
+		},
+		{
+			name:    "missing value",
+			header:  []string{"k1"},
+			wantErr: true,
+		},
+		{
+			name:    "bad escape",
+			header:  []string{"k1=%zz"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for _, v := range tt.header {
+				h.Add(BaggageHeader, v)
+			}
+			ctx, err := Extract(context.Background(), h)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Extract() error = %v; wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			m := tag.FromContext(ctx)
+			for _, k := range []tag.Key{k1, k2} {
+				got, ok := m.Value(k)
+				want, wantOK := tt.want[k]
+				if ok != wantOK || got != want {
+					t.Errorf("Value(%v) = %q, %v; want %q, %v", k.Name(), got, ok, want, wantOK)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractNoHeader(t *testing.T) {
+	ctx := context.Background()
+	got, err := Extract(ctx, http.Header{})
+	if err != nil || got != ctx {
+		t.Errorf("Extract() = %v, %v; want the context unchanged", got, err)
+	}
+}
@@ -43,7 +43,8 @@ type Annotation struct {
 }
 
 // Attribute represents a key-value pair on a span, link or annotation.
-// Construct with one of: BoolAttribute, Int64Attribute, or StringAttribute.
+// Construct with one of: BoolAttribute, Int64Attribute, Float64Attribute,
+// StringAttribute, Int64SliceAttribute, or StringSliceAttribute.
 type Attribute struct {
 	key   string
 	value interface{}
@@ -79,6 +80,18 @@ func StringAttribute(key string, value string) Attribute {
 	return Attribute{key: key, value: value}
 }
 
+// Int64SliceAttribute returns a []int64-valued attribute. The slice is
+// copied.
+func Int64SliceAttribute(key string, value []int64) Attribute {
+	return Attribute{key: key, value: append([]int64(nil), value...)}
+}
+
+// StringSliceAttribute returns a []string-valued attribute. The slice is
+// copied.
+func StringSliceAttribute(key string, value []string) Attribute {
+	return Attribute{key: key, value: append([]string(nil), value...)}
+}
+
 // LinkType specifies the relationship between the span that had the link
 // added, and the linked span.
 type LinkType int32
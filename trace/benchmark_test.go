@@ -70,6 +70,32 @@ func BenchmarkSpanWithAnnotations_8(b *testing.B) {
 	})
 }
 
+func BenchmarkUnsampledSpanAttributes(b *testing.B) {
+	ctx, span := StartSpan(context.Background(), "/foo", WithSampler(NeverSample()))
+	defer span.End()
+
+	b.Run("AddAttributes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			FromContext(ctx).AddAttributes(
+				StringAttribute("key1", "hello"),
+				Int64Attribute("key2", int64(i)),
+			)
+		}
+	})
+	b.Run("AttributesEnabled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if AttributesEnabled(ctx) {
+				FromContext(ctx).AddAttributes(
+					StringAttribute("key1", "hello"),
+					Int64Attribute("key2", int64(i)),
+				)
+			}
+		}
+	})
+}
+
 func BenchmarkTraceID_DotString(b *testing.B) {
 	traceBenchmark(b, func(b *testing.B) {
 		t := TraceID{0x0D, 0x0E, 0x0A, 0x0D, 0x0B, 0x0E, 0x0E, 0x0F, 0x0F, 0x0E, 0x0E, 0x0B, 0x0D, 0x0A, 0x0E, 0x0D}
@@ -16,8 +16,7 @@ package trace
 
 import (
 	"sync"
-
-	"go.opencensus.io/trace/internal"
+	"time"
 )
 
 // Config represents the global tracing configuration.
@@ -25,20 +24,76 @@ type Config struct {
 	// DefaultSampler is the default sampler used when creating new spans.
 	DefaultSampler Sampler
 
-	// IDGenerator is for internal use only.
-	IDGenerator internal.IDGenerator
+	// IDGenerator generates the trace and span IDs of new spans. It can be
+	// replaced, for example, to produce deterministic IDs in tests.
+	IDGenerator IDGenerator
 
-	// MaxAnnotationEventsPerSpan is max number of annotation events per span
+	// MaxAnnotationEventsPerSpan is max number of annotation events per span.
+	// Once the limit is reached the oldest annotation is dropped and counted
+	// in SpanData.DroppedAnnotationCount.
 	MaxAnnotationEventsPerSpan int
 
-	// MaxMessageEventsPerSpan is max number of message events per span
+	// MaxMessageEventsPerSpan is max number of message events per span.
+	// Once the limit is reached the oldest message event is dropped and
+	// counted in SpanData.DroppedMessageEventCount.
 	MaxMessageEventsPerSpan int
 
-	// MaxAnnotationEventsPerSpan is max number of attributes per span
+	// MaxAttributesPerSpan is max number of attributes per span.
+	// Once the limit is reached the least recently set attribute is dropped
+	// and counted in SpanData.DroppedAttributeCount.
 	MaxAttributesPerSpan int
 
-	// MaxLinksPerSpan is max number of links per span
+	// MaxAnnotationMessageLength, if positive, is the max length in bytes
+	// of annotation messages. Longer messages are truncated and end with
+	// "...". By default messages are not truncated; a negative value
+	// restores that behavior.
+	MaxAnnotationMessageLength int
+
+	// MaxLinksPerSpan is max number of links per span.
+	// Once the limit is reached the oldest link is dropped and counted in
+	// SpanData.DroppedLinkCount.
 	MaxLinksPerSpan int
+
+	// MaxExportBatchSize is the max number of spans passed to a single
+	// BatchExporter.ExportSpans call. It applies to exporters registered
+	// after the config is applied.
+	MaxExportBatchSize int
+
+	// ExportBatchInterval is the max time spans are held before being passed
+	// to a BatchExporter. It applies to exporters registered after the config
+	// is applied.
+	ExportBatchInterval time.Duration
+
+	// ExportQueueSize, if positive, is the number of ended spans queued for
+	// each Exporter that does not implement BatchExporter. Spans are then
+	// exported from another goroutine instead of from Span.End, and dropped
+	// when the queue is full; see ExportStats. It applies to exporters
+	// registered after the config is applied. By default spans are exported
+	// synchronously; a negative value restores that behavior.
+	ExportQueueSize int
+
+	// MaxSpansPerSpanStoreBucket is the number of spans kept per latency and
+	// error bucket by the local span store (see go.opencensus.io/zpages).
+	// It applies to span names first seen after the config is applied; use
+	// SetSpanStoreSize to resize the store of a given name.
+	MaxSpansPerSpanStoreBucket int
+
+	// TraceIDBits is the number of random bits in the trace IDs of new
+	// traces made by the default IDGenerator, 64 or 128 (the default). With
+	// 64, the high 8 bytes of trace IDs are zero, for backends that only
+	// accept 64-bit trace IDs, and the low 8 bytes, which ProbabilitySampler
+	// uses, stay random. With only 64 random bits, trace IDs are likely to
+	// collide after about 2^32 traces rather than 2^64. Other values are
+	// ignored. A custom IDGenerator decides the bits of its own trace IDs.
+	TraceIDBits int
+}
+
+// IDGenerator allows custom generators for trace and span IDs.
+//
+// Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	NewTraceID() [16]byte
+	NewSpanID() [8]byte
 }
 
 var configWriteMu sync.Mutex
@@ -55,6 +110,15 @@ const (
 
 	// DefaultMaxLinksPerSpan is default max number of links per span
 	DefaultMaxLinksPerSpan = 32
+
+	// DefaultMaxExportBatchSize is default max number of spans per export batch
+	DefaultMaxExportBatchSize = 512
+
+	// DefaultExportBatchInterval is default max delay before a batch is exported
+	DefaultExportBatchInterval = 5 * time.Second
+
+	// DefaultMaxSpansPerSpanStoreBucket is default number of spans kept per local span store bucket
+	DefaultMaxSpansPerSpanStoreBucket = 10
 )
 
 // ApplyConfig applies changes to the global tracing configuration.
@@ -79,8 +143,33 @@ func ApplyConfig(cfg Config) {
 	if cfg.MaxAttributesPerSpan > 0 {
 		c.MaxAttributesPerSpan = cfg.MaxAttributesPerSpan
 	}
+	if cfg.MaxAnnotationMessageLength > 0 {
+		c.MaxAnnotationMessageLength = cfg.MaxAnnotationMessageLength
+	} else if cfg.MaxAnnotationMessageLength < 0 {
+		c.MaxAnnotationMessageLength = 0
+	}
 	if cfg.MaxLinksPerSpan > 0 {
 		c.MaxLinksPerSpan = cfg.MaxLinksPerSpan
 	}
+	if cfg.MaxExportBatchSize > 0 {
+		c.MaxExportBatchSize = cfg.MaxExportBatchSize
+	}
+	if cfg.ExportBatchInterval > 0 {
+		c.ExportBatchInterval = cfg.ExportBatchInterval
+	}
+	if cfg.ExportQueueSize > 0 {
+		c.ExportQueueSize = cfg.ExportQueueSize
+	} else if cfg.ExportQueueSize < 0 {
+		c.ExportQueueSize = 0
+	}
+	if cfg.TraceIDBits == 64 || cfg.TraceIDBits == 128 {
+		c.TraceIDBits = cfg.TraceIDBits
+	}
+	if cfg.MaxSpansPerSpanStoreBucket > 0 {
+		c.MaxSpansPerSpanStoreBucket = cfg.MaxSpansPerSpanStoreBucket
+		if c.MaxSpansPerSpanStoreBucket > maxBucketSize {
+			c.MaxSpansPerSpanStoreBucket = maxBucketSize
+		}
+	}
 	config.Store(&c)
 }
@@ -15,7 +15,9 @@
 package trace
 
 import (
+	"context"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -107,3 +109,91 @@ func TestApplyConfig(t *testing.T) {
 
 	}
 }
+
+type sequentialIDGenerator struct {
+	mu   sync.Mutex
+	next byte
+}
+
+func (g *sequentialIDGenerator) NewTraceID() [16]byte {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	return [16]byte{15: g.next}
+}
+
+func (g *sequentialIDGenerator) NewSpanID() [8]byte {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	return [8]byte{7: g.next}
+}
+
+func TestApplyConfigIDGenerator(t *testing.T) {
+	defaultGen := config.Load().(*Config).IDGenerator
+	defer ApplyConfig(Config{IDGenerator: defaultGen})
+
+	ApplyConfig(Config{IDGenerator: &sequentialIDGenerator{}})
+	_, span := StartSpan(context.Background(), "foo")
+	sc := span.SpanContext()
+	if got, want := sc.TraceID, (TraceID{15: 1}); got != want {
+		t.Errorf("TraceID = %v; want %v", got, want)
+	}
+	if got, want := sc.SpanID, (SpanID{7: 2}); got != want {
+		t.Errorf("SpanID = %v; want %v", got, want)
+	}
+}
+
+type fixedIDGenerator struct{}
+
+func (fixedIDGenerator) NewTraceID() [16]byte { return [16]byte{0: 1, 15: 1} }
+
+func (fixedIDGenerator) NewSpanID() [8]byte { return [8]byte{7: 1} }
+
+func TestTraceIDBits(t *testing.T) {
+	defer ApplyConfig(Config{TraceIDBits: 128})
+
+	// highBitsSet returns whether any of 100 new trace IDs has a non-zero
+	// high byte, which is all but certain for random IDs.
+	highBitsSet := func() bool {
+		set := false
+		for i := 0; i < 100; i++ {
+			_, span := StartSpan(context.Background(), "foo")
+			tid := span.SpanContext().TraceID
+			if tid == (TraceID{}) {
+				t.Fatal("zero trace ID")
+			}
+			for _, b := range tid[:8] {
+				set = set || b != 0
+			}
+		}
+		return set
+	}
+
+	if !highBitsSet() {
+		t.Error("high bytes of 128-bit trace IDs are all zero")
+	}
+	ApplyConfig(Config{TraceIDBits: 64})
+	if highBitsSet() {
+		t.Error("high bytes of trace IDs are set with 64 TraceIDBits")
+	}
+	defaultGen := config.Load().(*Config).IDGenerator
+	ApplyConfig(Config{IDGenerator: fixedIDGenerator{}})
+	_, span := StartSpan(context.Background(), "foo")
+	if got, want := span.SpanContext().TraceID, (TraceID{0: 1, 15: 1}); got != want {
+		t.Errorf("TraceID from a custom IDGenerator = %v; want %v", got, want)
+	}
+	ApplyConfig(Config{IDGenerator: defaultGen})
+	ApplyConfig(Config{})
+	if highBitsSet() {
+		t.Error("TraceIDBits was reset by an empty Config")
+	}
+	ApplyConfig(Config{TraceIDBits: 32})
+	if highBitsSet() {
+		t.Error("TraceIDBits was changed by an invalid value")
+	}
+	ApplyConfig(Config{TraceIDBits: 128})
+	if !highBitsSet() {
+		t.Error("high bytes of trace IDs are all zero with 128 TraceIDBits")
+	}
+}
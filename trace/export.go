@@ -15,6 +15,8 @@
 package trace
 
 import (
+	"context"
+	"encoding/json"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -31,7 +33,125 @@ type Exporter interface {
 	ExportSpan(s *SpanData)
 }
 
-type exportersMap map[Exporter]struct{}
+// BatchExporter is an optional interface that an Exporter can implement to
+// receive sampled trace spans in batches rather than one at a time.
+//
+// When a registered Exporter implements BatchExporter, spans are accumulated
+// and passed to ExportSpans once Config.MaxExportBatchSize spans are pending
+// or Config.ExportBatchInterval has elapsed, whichever comes first. ExportSpan
+// is not called for such exporters.
+//
+// The SpanData in the slice should not be modified, but pointers to them can
+// be kept. The slice itself is not reused.
+type BatchExporter interface {
+	Exporter
+	ExportSpans(sds []*SpanData)
+}
+
+// BatchErrorExporter is an optional interface that a BatchExporter can
+// implement to report the errors of its exports. Batches are then passed to
+// ExportSpansWithError instead of ExportSpans, and the errors it returns are
+// passed to the handler set with SetExportErrorHandler.
+type BatchErrorExporter interface {
+	BatchExporter
+	ExportSpansWithError(sds []*SpanData) error
+}
+
+// ExportFilter is an optional interface that an Exporter can implement to
+// subsample the sampled spans it receives, for example to send every span to
+// a local sink but only a fraction of them to an expensive backend.
+//
+// ShouldExport is called when a span ends, before the span is passed to the
+// exporter, batched or queued; the span is skipped for that exporter only if
+// it returns false. It must be safe for concurrent use and fast. Exporters
+// that do not implement ExportFilter receive all sampled spans.
+type ExportFilter interface {
+	ShouldExport(sd *SpanData) bool
+}
+
+// Flusher is an optional interface that an Exporter can implement to export
+// the spans it buffers when Shutdown is called.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// exportersMap holds the registered exporters.
+type exportersMap map[Exporter]exporterEntry
+
+// exporterEntry is the state of a registered exporter.
+type exporterEntry struct {
+	// sink is where spans are handed to for exporters implementing
+	// BatchExporter or registered with a Config.ExportQueueSize, and nil for
+	// exporters called synchronously.
+	sink spanSink
+	// refs is the number of times the exporter was registered.
+	refs int
+}
+
+// spanSink receives ended spans on behalf of an exporter.
+type spanSink interface {
+	add(sd *SpanData)
+	// stop exports or drops all pending spans and releases resources.
+	stop()
+}
+
+// ExportStatistics holds counters about the spans handed to exporters.
+type ExportStatistics struct {
+	// SpansExported is the number of spans passed to exporters. Spans that
+	// a BatchErrorExporter failed to export are not counted.
+	SpansExported int64
+	// SpansDropped is the number of spans dropped because the queue of an
+	// exporter was full. See Config.ExportQueueSize.
+	SpansDropped int64
+}
+
+var exportedSpans, droppedSpans int64 // accessed atomically
+
+// ExportStats returns counters about the spans handed to exporters since
+// the program started. They are summed over all exporters, so a span
+// exported to two exporters is counted twice.
+func ExportStats() ExportStatistics {
+	return ExportStatistics{
+		SpansExported: atomic.LoadInt64(&exportedSpans),
+		SpansDropped:  atomic.LoadInt64(&droppedSpans),
+	}
+}
+
+// exportSpan passes sd to every exporter in exp.
+func (exp exportersMap) exportSpan(sd *SpanData) {
+	for e, entry := range exp {
+		if f, ok := e.(ExportFilter); ok && !f.ShouldExport(sd) {
+			continue
+		}
+		if entry.sink != nil {
+			entry.sink.add(sd)
+			continue
+		}
+		e.ExportSpan(sd)
+		atomic.AddInt64(&exportedSpans, 1)
+	}
+}
+
+var exportErrorHandler atomic.Value // holds a func(error)
+
+// SetExportErrorHandler sets the function called with the errors of span
+// exports, for example to route them to alerting. It receives the errors
+// returned by BatchErrorExporter.ExportSpansWithError and those that
+// exporters pass to ReportExportError. Errors are dropped if no handler is
+// set, which is the default; a nil h restores that behavior.
+//
+// h may be called concurrently from several goroutines.
+func SetExportErrorHandler(h func(error)) {
+	exportErrorHandler.Store(h)
+}
+
+// ReportExportError passes err to the handler set with SetExportErrorHandler,
+// if any. Exporters can call it when they fail to export spans.
+func ReportExportError(err error) {
+	if h, _ := exportErrorHandler.Load().(func(error)); h != nil {
+		h(err)
+	}
+}
 
 var (
 	exporterMu sync.Mutex
@@ -41,33 +161,267 @@ var (
 // RegisterExporter adds to the list of Exporters that will receive sampled
 // trace spans.
 //
+// If e implements BatchExporter, spans are batched using the batch size and
+// interval of the current Config. Otherwise, if the current Config has an
+// ExportQueueSize, spans are queued and exported from another goroutine.
+//
+// Registering an exporter that is already registered does not change how
+// spans are exported to it, but it then stays registered until
+// UnregisterExporter has been called as many times as RegisterExporter.
+//
 // Binaries can register exporters, libraries shouldn't register exporters.
 func RegisterExporter(e Exporter) {
 	exporterMu.Lock()
 	new := make(exportersMap)
-	if old, ok := exporters.Load().(exportersMap); ok {
-		for k, v := range old {
-			new[k] = v
+	old, _ := exporters.Load().(exportersMap)
+	for k, v := range old {
+		new[k] = v
+	}
+	entry, ok := new[e]
+	if !ok {
+		cfg := config.Load().(*Config)
+		if be, ok := e.(BatchExporter); ok {
+			entry.sink = newSpanBatcher(be, cfg.MaxExportBatchSize, cfg.ExportBatchInterval)
+		} else if cfg.ExportQueueSize > 0 {
+			entry.sink = newSpanQueue(e, cfg.ExportQueueSize)
 		}
 	}
-	new[e] = struct{}{}
+	entry.refs++
+	new[e] = entry
 	exporters.Store(new)
 	exporterMu.Unlock()
 }
 
 // UnregisterExporter removes from the list of Exporters the Exporter that was
-// registered with the given name.
+// registered with the given name. If e was registered several times, it is
+// only removed by the last matching call to UnregisterExporter.
+//
+// If e implements BatchExporter or has a queue, spans still pending are
+// exported before the call removing e returns.
 func UnregisterExporter(e Exporter) {
 	exporterMu.Lock()
+	old, _ := exporters.Load().(exportersMap)
+	entry, ok := old[e]
+	if !ok {
+		exporterMu.Unlock()
+		return
+	}
 	new := make(exportersMap)
-	if old, ok := exporters.Load().(exportersMap); ok {
-		for k, v := range old {
-			new[k] = v
-		}
+	for k, v := range old {
+		new[k] = v
+	}
+	if entry.refs--; entry.refs > 0 {
+		new[e] = entry
+	} else {
+		delete(new, e)
 	}
-	delete(new, e)
 	exporters.Store(new)
 	exporterMu.Unlock()
+
+	if entry.refs == 0 && entry.sink != nil {
+		entry.sink.stop()
+	}
+}
+
+// Shutdown unregisters all exporters, exporting the spans still pending in
+// their batches and queues, and then calls Flush on the exporters that
+// implement Flusher. Exporters that do not implement it are skipped.
+//
+// It returns the first error returned by Flush, or the error of ctx if ctx
+// is done before all exporters are flushed. It is intended to be called
+// once, when the program exits.
+func Shutdown(ctx context.Context) error {
+	exporterMu.Lock()
+	old, _ := exporters.Load().(exportersMap)
+	exporters.Store(make(exportersMap))
+	exporterMu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		for _, entry := range old {
+			if entry.sink != nil {
+				entry.sink.stop()
+			}
+		}
+		var err error
+		for e := range old {
+			if f, ok := e.(Flusher); ok {
+				if ferr := f.Flush(ctx); ferr != nil && err == nil {
+					err = ferr
+				}
+			}
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// spanBatcher accumulates spans for a BatchExporter and exports them from its
+// own goroutine when a batch is full or when the flush interval elapses.
+type spanBatcher struct {
+	exporter BatchExporter
+	size     int
+
+	mu     sync.Mutex
+	closed bool
+	spans  []*SpanData
+
+	full       chan struct{}
+	quit, done chan struct{}
+}
+
+func newSpanBatcher(e BatchExporter, size int, interval time.Duration) *spanBatcher {
+	b := &spanBatcher{
+		exporter: e,
+		size:     size,
+		full:     make(chan struct{}, 1),
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go b.start(interval)
+	return b
+}
+
+func (b *spanBatcher) start(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.full:
+			b.export(b.take(false))
+		case <-ticker.C:
+			b.export(b.take(true))
+		case <-b.quit:
+			b.export(b.take(true))
+			close(b.done)
+			return
+		}
+	}
+}
+
+// add queues sd, waking up the export goroutine if a batch is full. Spans
+// added after stop are dropped.
+func (b *spanBatcher) add(sd *SpanData) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.spans = append(b.spans, sd)
+	full := len(b.spans) >= b.size
+	b.mu.Unlock()
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+			// The export goroutine is already woken up.
+		}
+	}
+}
+
+// take removes and returns the pending spans. Unless all is true, the spans
+// that do not fill a whole batch are left pending.
+func (b *spanBatcher) take(all bool) []*SpanData {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := len(b.spans)
+	if !all {
+		n -= n % b.size
+	}
+	spans := b.spans[:n:n]
+	b.spans = append([]*SpanData(nil), b.spans[n:]...)
+	return spans
+}
+
+// export passes spans to the exporter in batches of at most size spans.
+func (b *spanBatcher) export(spans []*SpanData) {
+	for len(spans) > 0 {
+		n := b.size
+		if n > len(spans) {
+			n = len(spans)
+		}
+		b.exportBatch(spans[:n:n])
+		spans = spans[n:]
+	}
+}
+
+func (b *spanBatcher) exportBatch(spans []*SpanData) {
+	if e, ok := b.exporter.(BatchErrorExporter); ok {
+		if err := e.ExportSpansWithError(spans); err != nil {
+			ReportExportError(err)
+			return
+		}
+	} else {
+		b.exporter.ExportSpans(spans)
+	}
+	atomic.AddInt64(&exportedSpans, int64(len(spans)))
+}
+
+// stop exports all pending spans and stops the export goroutine.
+func (b *spanBatcher) stop() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	close(b.quit)
+	<-b.done
+}
+
+// spanQueue exports spans to an Exporter from its own goroutine, dropping
+// spans when the exporter cannot keep up and the queue is full.
+type spanQueue struct {
+	exporter Exporter
+
+	mu     sync.RWMutex
+	closed bool
+	c      chan *SpanData
+
+	done chan struct{}
+}
+
+func newSpanQueue(e Exporter, size int) *spanQueue {
+	q := &spanQueue{
+		exporter: e,
+		c:        make(chan *SpanData, size),
+		done:     make(chan struct{}),
+	}
+	go q.start()
+	return q
+}
+
+func (q *spanQueue) start() {
+	for sd := range q.c {
+		q.exporter.ExportSpan(sd)
+		atomic.AddInt64(&exportedSpans, 1)
+	}
+	close(q.done)
+}
+
+// add queues sd, or drops it if the queue is full.
+func (q *spanQueue) add(sd *SpanData) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return
+	}
+	select {
+	case q.c <- sd:
+	default:
+		atomic.AddInt64(&droppedSpans, 1)
+	}
+}
+
+// stop exports all queued spans and stops the export goroutine.
+func (q *spanQueue) stop() {
+	q.mu.Lock()
+	q.closed = true
+	close(q.c)
+	q.mu.Unlock()
+	<-q.done
 }
 
 // SpanData contains all the information collected by a Span.
@@ -80,7 +434,8 @@ type SpanData struct {
 	// The wall clock time of EndTime will be adjusted to always be offset
 	// from StartTime by the duration of the span.
 	EndTime time.Time
-	// The values of Attributes each have type string, bool, or int64.
+	// The values of Attributes each have type string, bool, int64, float64,
+	// []string, or []int64.
 	Attributes    map[string]interface{}
 	Annotations   []Annotation
 	MessageEvents []MessageEvent
@@ -95,3 +450,33 @@ type SpanData struct {
 	// ChildSpanCount holds the number of child span created for this span.
 	ChildSpanCount int
 }
+
+// MarshalJSON encodes sd field by field, as encoding/json would without the
+// text methods SpanData gets from its embedded SpanContext.
+func (sd SpanData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(spanDataJSON{spanData: (*spanData)(&sd)})
+}
+
+// UnmarshalJSON decodes the encoding written by MarshalJSON.
+func (sd *SpanData) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &spanDataJSON{spanData: (*spanData)(sd)})
+}
+
+// spanData has the fields of SpanData without its JSON methods.
+type spanData SpanData
+
+// spanDataJSON has no text methods, so encoding/json uses the fields of
+// spanData: those promoted from the SpanContext in spanData are ambiguous
+// with those of the noText in textConflict, which is at the same depth.
+type spanDataJSON struct {
+	*spanData
+	textConflict
+}
+
+type textConflict struct{ noText }
+
+type noText struct{}
+
+func (noText) MarshalText() ([]byte, error) { return nil, nil }
+
+func (*noText) UnmarshalText([]byte) error { return nil }
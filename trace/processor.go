@@ -0,0 +1,107 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SpanProcessor is notified when the spans recording events start and end,
+// sampled or not. Its methods are called synchronously from StartSpan and
+// End, so they should be safe for concurrent use and return quickly.
+type SpanProcessor interface {
+	// OnStart is called when a span starts, with a SpanData holding the
+	// initial state of the span and an empty Attributes map. The attributes
+	// added to sd.Attributes are recorded on the span; other changes to sd
+	// are ignored.
+	OnStart(sd *SpanData)
+
+	// OnEnd is called when a span ends, after the callbacks registered with
+	// Span.OnEnd and before the SpanData is handed to exporters. It may
+	// modify sd, for example to add attributes or set the status.
+	OnEnd(sd *SpanData)
+}
+
+var (
+	processorMu sync.Mutex
+	processors  atomic.Value // []SpanProcessor
+)
+
+// RegisterSpanProcessor adds p to the processors notified of spans starting
+// and ending. Processors are called in registration order.
+//
+// Binaries can register processors, libraries shouldn't register processors.
+func RegisterSpanProcessor(p SpanProcessor) {
+	processorMu.Lock()
+	old, _ := processors.Load().([]SpanProcessor)
+	new := make([]SpanProcessor, len(old), len(old)+1)
+	copy(new, old)
+	processors.Store(append(new, p))
+	processorMu.Unlock()
+}
+
+// UnregisterSpanProcessor removes p from the registered processors. If p was
+// registered several times, only its first registration is removed.
+func UnregisterSpanProcessor(p SpanProcessor) {
+	processorMu.Lock()
+	defer processorMu.Unlock()
+	old, _ := processors.Load().([]SpanProcessor)
+	for i, q := range old {
+		if q == p {
+			new := make([]SpanProcessor, 0, len(old)-1)
+			new = append(new, old[:i]...)
+			processors.Store(append(new, old[i+1:]...))
+			return
+		}
+	}
+}
+
+// ExporterProcessor returns a SpanProcessor passing the sampled spans to e
+// when they end. Unlike RegisterExporter, it never batches nor queues spans,
+// and e sees the changes made by the processors registered before it.
+func ExporterProcessor(e Exporter) SpanProcessor {
+	return exporterProcessor{e}
+}
+
+type exporterProcessor struct {
+	e Exporter
+}
+
+func (p exporterProcessor) OnStart(sd *SpanData) {}
+
+func (p exporterProcessor) OnEnd(sd *SpanData) {
+	if sd.IsSampled() {
+		p.e.ExportSpan(sd)
+		atomic.AddInt64(&exportedSpans, 1)
+	}
+}
+
+// startProcessors calls OnStart on the registered processors and records
+// the attributes they add on s. s must not be visible to other goroutines.
+func (s *span) startProcessors(procs []SpanProcessor) {
+	sd := *s.data
+	sd.Attributes = make(map[string]interface{})
+	if len(s.links.queue) > 0 {
+		sd.Links = s.interfaceArrayToLinksArray()
+		sd.DroppedLinkCount = s.links.droppedCount
+	}
+	for _, p := range procs {
+		p.OnStart(&sd)
+	}
+	for k, v := range sd.Attributes {
+		s.lruAttributes.add(k, v)
+	}
+}
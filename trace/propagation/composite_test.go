@@ -0,0 +1,71 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"net/http"
+	"testing"
+
+	"go.opencensus.io/plugin/ochttp/propagation/b3"
+	"go.opencensus.io/plugin/ochttp/propagation/jaeger"
+	"go.opencensus.io/plugin/ochttp/propagation/tracecontext"
+	"go.opencensus.io/trace"
+	"go.opencensus.io/trace/propagation"
+)
+
+func TestComposite(t *testing.T) {
+	format := propagation.Composite(&tracecontext.HTTPFormat{}, &b3.HTTPFormat{}, &jaeger.HTTPFormat{})
+	want := trace.SpanContext{
+		TraceID:      trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:       trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceOptions: 1,
+	}
+
+	tests := []struct {
+		name   string
+		header http.Header
+	}{
+		{"w3c", http.Header{"Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}},
+		{"b3", http.Header{
+			"X-B3-Traceid": {"4bf92f3577b34da6a3ce929d0e0e4736"},
+			"X-B3-Spanid":  {"00f067aa0ba902b7"},
+			"X-B3-Sampled": {"1"},
+		}},
+		{"jaeger", http.Header{"Uber-Trace-Id": {"4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "http://example.com", nil)
+			req.Header = tt.header
+			sc, ok := format.SpanContextFromRequest(req)
+			if !ok || sc.TraceID != want.TraceID || sc.SpanID != want.SpanID || sc.TraceOptions != want.TraceOptions {
+				t.Errorf("SpanContextFromRequest() = %v, %t; want %v, true", sc, ok, want)
+			}
+		})
+	}
+
+	req, _ := http.NewRequest("GET", "http://example.com", nil)
+	if sc, ok := format.SpanContextFromRequest(req); ok {
+		t.Errorf("SpanContextFromRequest() = %v, true for a request without headers", sc)
+	}
+
+	// Every format can extract the injected span context.
+	format.SpanContextToRequest(want, req)
+	for _, f := range []propagation.HTTPFormat{&tracecontext.HTTPFormat{}, &b3.HTTPFormat{}, &jaeger.HTTPFormat{}} {
+		if sc, ok := f.SpanContextFromRequest(req); !ok || sc.TraceID != want.TraceID || sc.SpanID != want.SpanID {
+			t.Errorf("%T.SpanContextFromRequest() = %v, %t after injection; want %v", f, sc, ok, want)
+		}
+	}
+}
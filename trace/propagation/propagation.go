@@ -106,3 +106,29 @@ type HTTPFormat interface {
 }
 
 // TODO(jbd): Find a more representative but short name for HTTPFormat.
+
+// Composite returns an HTTPFormat combining formats, for servers receiving
+// requests from clients that use different formats. SpanContextFromRequest
+// returns the span context extracted by the first of formats that finds one
+// in the request, and SpanContextToRequest injects the span context in the
+// request with each of formats, so that any of them can extract it.
+func Composite(formats ...HTTPFormat) HTTPFormat {
+	return compositeFormat(append([]HTTPFormat(nil), formats...))
+}
+
+type compositeFormat []HTTPFormat
+
+func (c compositeFormat) SpanContextFromRequest(req *http.Request) (sc trace.SpanContext, ok bool) {
+	for _, f := range c {
+		if sc, ok := f.SpanContextFromRequest(req); ok {
+			return sc, true
+		}
+	}
+	return trace.SpanContext{}, false
+}
+
+func (c compositeFormat) SpanContextToRequest(sc trace.SpanContext, req *http.Request) {
+	for _, f := range c {
+		f.SpanContextToRequest(sc, req)
+	}
+}
@@ -16,6 +16,7 @@ package trace
 
 import (
 	"encoding/binary"
+	"reflect"
 )
 
 const defaultSamplingProbability = 1e-4
@@ -30,16 +31,68 @@ type SamplingParameters struct {
 	SpanID          SpanID
 	Name            string
 	HasRemoteParent bool
+
+	// report, if non-nil, asks the sampler to report its configuration
+	// rather than make a decision. See SamplerFraction.
+	report *samplerReport
+}
+
+// samplerReport is filled in by the samplers of this package when they are
+// queried by SamplerFraction.
+type samplerReport struct {
+	fraction float64
+	ok       bool
+}
+
+// SamplerFraction returns the fraction of traces sampled by s. ok is false
+// if the fraction is not known, which is the case for samplers that were not
+// created by ProbabilitySampler, AlwaysSample, NeverSample, ParentBased or
+// NameBasedSampler with a known fraction.
+//
+// SamplerFraction does not call samplers created elsewhere, so it is safe to
+// pass samplers with side effects, such as rate limiters.
+func SamplerFraction(s Sampler) (fraction float64, ok bool) {
+	if s == nil || !reportingSamplers[reflect.ValueOf(s).Pointer()] {
+		return 0, false
+	}
+	var r samplerReport
+	s(SamplingParameters{report: &r})
+	return r.fraction, r.ok
+}
+
+// reportingSamplers holds the code pointers of the samplers created by this
+// package that report their fraction when SamplingParameters.report is set.
+// Their constructors are not inlined, so that each has a single code pointer.
+var reportingSamplers = map[uintptr]bool{}
+
+func init() {
+	for _, s := range []Sampler{
+		ProbabilitySampler(0.5),
+		AlwaysSample(),
+		NeverSample(),
+		ParentBased(nil),
+		NameBasedSampler(nil, nil),
+	} {
+		reportingSamplers[reflect.ValueOf(s).Pointer()] = true
+	}
 }
 
 // SamplingDecision is the value returned by a Sampler.
 type SamplingDecision struct {
 	Sample bool
+
+	// RecordOnly, if Sample is false, makes the span record events and be
+	// added to the local span store (see go.opencensus.io/zpages) without
+	// being sampled, so it is not passed to the exporters. The decision is
+	// not propagated to child spans.
+	RecordOnly bool
 }
 
 // ProbabilitySampler returns a Sampler that samples a given fraction of traces.
 //
 // It also samples spans whose parents are sampled.
+//
+//go:noinline
 func ProbabilitySampler(fraction float64) Sampler {
 	if !(fraction >= 0) {
 		fraction = 0
@@ -49,10 +102,16 @@ func ProbabilitySampler(fraction float64) Sampler {
 
 	traceIDUpperBound := uint64(fraction * (1 << 63))
 	return Sampler(func(p SamplingParameters) SamplingDecision {
+		if p.report != nil {
+			p.report.fraction, p.report.ok = fraction, true
+			return SamplingDecision{}
+		}
 		if p.ParentContext.IsSampled() {
 			return SamplingDecision{Sample: true}
 		}
-		x := binary.BigEndian.Uint64(p.TraceID[0:8]) >> 1
+		// Use the low 8 bytes so that 64-bit trace IDs, which leave the
+		// upper bytes zeroed, are sampled at the configured rate.
+		x := binary.BigEndian.Uint64(p.TraceID[8:16]) >> 1
 		return SamplingDecision{Sample: x < traceIDUpperBound}
 	})
 }
@@ -61,15 +120,118 @@ func ProbabilitySampler(fraction float64) Sampler {
 // Be careful about using this sampler in a production application with
 // significant traffic: a new trace will be started and exported for every
 // request.
+//
+//go:noinline
 func AlwaysSample() Sampler {
 	return func(p SamplingParameters) SamplingDecision {
+		if p.report != nil {
+			p.report.fraction, p.report.ok = 1, true
+		}
 		return SamplingDecision{Sample: true}
 	}
 }
 
 // NeverSample returns a Sampler that samples no traces.
+//
+//go:noinline
 func NeverSample() Sampler {
 	return func(p SamplingParameters) SamplingDecision {
+		if p.report != nil {
+			p.report.fraction, p.report.ok = 0, true
+		}
 		return SamplingDecision{Sample: false}
 	}
 }
+
+// ParentBased returns a Sampler that follows the sampling decision of the
+// parent span when there is one, and consults root for spans that start a
+// new trace.
+//
+// SamplerFraction of a ParentBased sampler reports the fraction of root.
+//
+//go:noinline
+func ParentBased(root Sampler) Sampler {
+	return func(p SamplingParameters) SamplingDecision {
+		if p.report != nil {
+			p.report.fraction, p.report.ok = SamplerFraction(root)
+			return SamplingDecision{}
+		}
+		if p.ParentContext != (SpanContext{}) {
+			return SamplingDecision{Sample: p.ParentContext.IsSampled()}
+		}
+		return root(p)
+	}
+}
+
+// AllSamplers returns a Sampler that samples a span only if all samplers
+// do. Samplers are consulted in order until one neither samples nor records
+// the span, so later samplers, such as rate limiters, are only consulted
+// when the earlier ones agree. The span is recorded without being sampled
+// if every sampler either samples or records it, but not all sample it.
+// With no samplers, every span is sampled.
+//
+// SamplerFraction of the returned sampler is not known.
+func AllSamplers(samplers ...Sampler) Sampler {
+	return func(p SamplingParameters) SamplingDecision {
+		d := SamplingDecision{Sample: true}
+		for _, s := range samplers {
+			sd := s(p)
+			if !sd.Sample && !sd.RecordOnly {
+				return SamplingDecision{}
+			}
+			if !sd.Sample {
+				d = SamplingDecision{RecordOnly: true}
+			}
+		}
+		return d
+	}
+}
+
+// AnySampler returns a Sampler that samples a span if any of the samplers
+// does. Samplers are consulted in order until one samples the span. The
+// span is recorded without being sampled if no sampler samples it but one
+// records it. With no samplers, no span is sampled.
+//
+// SamplerFraction of the returned sampler is not known.
+func AnySampler(samplers ...Sampler) Sampler {
+	return func(p SamplingParameters) SamplingDecision {
+		var d SamplingDecision
+		for _, s := range samplers {
+			sd := s(p)
+			if sd.Sample {
+				return SamplingDecision{Sample: true}
+			}
+			d.RecordOnly = d.RecordOnly || sd.RecordOnly
+		}
+		return d
+	}
+}
+
+// NameBasedSampler returns a Sampler that picks the sampler to consult by
+// the name of the span: the sampler of rules whose key is exactly the span
+// name, or fallback if there is none. For example, the following sampler
+// never samples health checks and samples 1% of the other spans:
+//
+//	trace.NameBasedSampler(map[string]trace.Sampler{
+//		"/healthz": trace.NeverSample(),
+//	}, trace.ProbabilitySampler(0.01))
+//
+// The fraction reported by SamplerFraction is that of fallback.
+//
+//go:noinline
+func NameBasedSampler(rules map[string]Sampler, fallback Sampler) Sampler {
+	byName := make(map[string]Sampler, len(rules))
+	for name, s := range rules {
+		byName[name] = s
+	}
+	return func(p SamplingParameters) SamplingDecision {
+		if p.report != nil {
+			p.report.fraction, p.report.ok = SamplerFraction(fallback)
+			return SamplingDecision{}
+		}
+		if s, ok := byName[p.Name]; ok {
+			return s(p)
+		}
+		return fallback(p)
+	}
+}
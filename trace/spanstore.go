@@ -21,10 +21,7 @@ import (
 	"go.opencensus.io/internal"
 )
 
-const (
-	maxBucketSize     = 100000
-	defaultBucketSize = 10
-)
+const maxBucketSize = 100000
 
 var (
 	ssmu       sync.RWMutex // protects spanStores
@@ -89,6 +86,19 @@ func (i internalOnly) ReportSpansByError(name string, code int32) []*SpanData {
 	return out
 }
 
+// SetSpanStoreSize sets the number of spans the local span store keeps per
+// latency and error bucket for spans with the given name. Once a bucket is
+// full, the oldest span in it is evicted. Sizes above 100000 are capped.
+//
+// The default size for all names is set by Config.MaxSpansPerSpanStoreBucket.
+func SetSpanStoreSize(name string, maxSpansPerBucket int) {
+	internalOnly{}.ConfigureBucketSizes([]internal.BucketConfiguration{{
+		Name:                 name,
+		MaxRequestsSucceeded: maxSpansPerBucket,
+		MaxRequestsErrors:    maxSpansPerBucket,
+	}})
+}
+
 // ConfigureBucketSizes sets the number of spans to keep per latency and error
 // bucket for different span names.
 func (i internalOnly) ConfigureBucketSizes(bcs []internal.BucketConfiguration) {
@@ -233,7 +243,8 @@ func spanStoreForNameCreateIfNew(name string) *spanStore {
 	if ok {
 		return s
 	}
-	s = newSpanStore(name, defaultBucketSize, defaultBucketSize)
+	size := config.Load().(*Config).MaxSpansPerSpanStoreBucket
+	s = newSpanStore(name, size, size)
 	spanStores[name] = s
 	return s
 }
@@ -18,11 +18,14 @@ import (
 	"context"
 	crand "crypto/rand"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"go.opencensus.io/internal"
 	"go.opencensus.io/trace/tracestate"
@@ -54,12 +57,19 @@ type span struct {
 	// annotations are stored in FIFO queue capped by configured limit.
 	annotations *evictedQueue
 
+	// maxAnnotationMessageLength is the configured limit on the length of
+	// annotation messages, or 0 if unlimited.
+	maxAnnotationMessageLength int
+
 	// messageEvents are stored in FIFO queue capped by configured limit.
 	messageEvents *evictedQueue
 
 	// links are stored in FIFO queue capped by configured limit.
 	links *evictedQueue
 
+	// onEnd holds the callbacks registered with OnEnd, in registration order.
+	onEnd []func(*SpanData)
+
 	// spanStore is the spanStore this span belongs to, if any, otherwise it is nil.
 	*spanStore
 	endOnce sync.Once
@@ -107,7 +117,62 @@ type SpanContext struct {
 	TraceID      TraceID
 	SpanID       SpanID
 	TraceOptions TraceOptions
-	Tracestate   *tracestate.Tracestate
+	// Tracestate holds the vendor-specific entries of the W3C tracestate
+	// header. It is set by the tracecontext propagator, inherited by child
+	// spans and nil otherwise.
+	Tracestate *tracestate.Tracestate
+}
+
+// spanContextTextLen is the length of the text form of a SpanContext: 32
+// hex digits of trace ID, 16 of span ID and 2 of options, and 2 dashes.
+const spanContextTextLen = 52
+
+var errMalformedSpanContext = errors.New("trace: malformed span context text")
+
+// MarshalText implements encoding.TextMarshaler. The text form of a
+// SpanContext is "traceid-spanid-options", with the trace ID, span ID and low
+// byte of TraceOptions in lowercase hex, for example
+// "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01". The Tracestate is
+// not included.
+//
+// MarshalText allocates only the returned slice, so it is cheaper than
+// formatting the IDs with String.
+func (sc SpanContext) MarshalText() ([]byte, error) {
+	b := make([]byte, spanContextTextLen)
+	i := hex.Encode(b, sc.TraceID[:])
+	b[i] = '-'
+	i++
+	i += hex.Encode(b[i:], sc.SpanID[:])
+	b[i] = '-'
+	i++
+	opts := [1]byte{byte(sc.TraceOptions)}
+	hex.Encode(b[i:], opts[:])
+	return b, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for the text form written
+// by MarshalText. Uppercase hex digits are accepted. It returns an error and
+// leaves sc unchanged if text is malformed.
+func (sc *SpanContext) UnmarshalText(text []byte) error {
+	var (
+		parsed SpanContext
+		opts   [1]byte
+	)
+	if len(text) != spanContextTextLen || text[32] != '-' || text[49] != '-' {
+		return errMalformedSpanContext
+	}
+	if _, err := hex.Decode(parsed.TraceID[:], text[:32]); err != nil {
+		return errMalformedSpanContext
+	}
+	if _, err := hex.Decode(parsed.SpanID[:], text[33:49]); err != nil {
+		return errMalformedSpanContext
+	}
+	if _, err := hex.Decode(opts[:], text[50:]); err != nil {
+		return errMalformedSpanContext
+	}
+	parsed.TraceOptions = TraceOptions(opts[0])
+	*sc = parsed
+	return nil
 }
 
 type contextKey struct{}
@@ -145,6 +210,11 @@ type StartOptions struct {
 	// SpanKind represents the kind of a span. If none is set,
 	// SpanKindUnspecified is used.
 	SpanKind int
+
+	// Links are added to the span when it is created, before span
+	// processors are notified, for example to link a span consuming a
+	// batch of messages to the spans that produced them.
+	Links []Link
 }
 
 // StartOption apply changes to StartOptions.
@@ -157,6 +227,13 @@ func WithSpanKind(spanKind int) StartOption {
 	}
 }
 
+// WithLinks makes new spans to be created with the given links.
+func WithLinks(links ...Link) StartOption {
+	return func(o *StartOptions) {
+		o.Links = append(o.Links, links...)
+	}
+}
+
 // WithSampler makes new spans to be be created with a custom sampler.
 // Otherwise, the global sampler is used.
 func WithSampler(sampler Sampler) StartOption {
@@ -214,16 +291,22 @@ func startSpanInternal(name string, hasParent bool, parent SpanContext, remotePa
 	s.spanContext = parent
 
 	cfg := config.Load().(*Config)
-	if gen, ok := cfg.IDGenerator.(*defaultIDGenerator); ok {
+	gen, isDefault := cfg.IDGenerator.(*defaultIDGenerator)
+	if isDefault {
 		// lazy initialization
 		gen.init()
 	}
 
 	if !hasParent {
-		s.spanContext.TraceID = cfg.IDGenerator.NewTraceID()
+		if isDefault {
+			s.spanContext.TraceID = gen.newTraceID(cfg.TraceIDBits)
+		} else {
+			s.spanContext.TraceID = cfg.IDGenerator.NewTraceID()
+		}
 	}
 	s.spanContext.SpanID = cfg.IDGenerator.NewSpanID()
 	sampler := cfg.DefaultSampler
+	recordOnly := false
 
 	if !hasParent || remoteParent || o.Sampler != nil {
 		// If this span is the child of a local span and no Sampler is set in the
@@ -234,15 +317,17 @@ func startSpanInternal(name string, hasParent bool, parent SpanContext, remotePa
 		if o.Sampler != nil {
 			sampler = o.Sampler
 		}
-		s.spanContext.setIsSampled(sampler(SamplingParameters{
+		decision := sampler(SamplingParameters{
 			ParentContext:   parent,
 			TraceID:         s.spanContext.TraceID,
 			SpanID:          s.spanContext.SpanID,
 			Name:            name,
-			HasRemoteParent: remoteParent}).Sample)
+			HasRemoteParent: remoteParent})
+		s.spanContext.setIsSampled(decision.Sample)
+		recordOnly = decision.RecordOnly
 	}
 
-	if !internal.LocalSpanStoreEnabled && !s.spanContext.IsSampled() {
+	if !internal.LocalSpanStoreEnabled && !s.spanContext.IsSampled() && !recordOnly {
 		return s
 	}
 
@@ -255,12 +340,19 @@ func startSpanInternal(name string, hasParent bool, parent SpanContext, remotePa
 	}
 	s.lruAttributes = newLruMap(cfg.MaxAttributesPerSpan)
 	s.annotations = newEvictedQueue(cfg.MaxAnnotationEventsPerSpan)
+	s.maxAnnotationMessageLength = cfg.MaxAnnotationMessageLength
 	s.messageEvents = newEvictedQueue(cfg.MaxMessageEventsPerSpan)
 	s.links = newEvictedQueue(cfg.MaxLinksPerSpan)
 
 	if hasParent {
 		s.data.ParentSpanID = parent.SpanID
 	}
+	for _, l := range o.Links {
+		s.links.add(l)
+	}
+	if procs, _ := processors.Load().([]SpanProcessor); len(procs) > 0 {
+		s.startProcessors(procs)
+	}
 	if internal.LocalSpanStoreEnabled {
 		var ss *spanStore
 		ss = spanStoreForNameCreateIfNew(name)
@@ -287,16 +379,32 @@ func (s *span) End() {
 	s.endOnce.Do(func() {
 		exp, _ := exporters.Load().(exportersMap)
 		mustExport := s.spanContext.IsSampled() && len(exp) > 0
-		if s.spanStore != nil || mustExport {
+		procs, _ := processors.Load().([]SpanProcessor)
+		s.mu.Lock()
+		onEnd := s.onEnd
+		s.mu.Unlock()
+		if s.spanStore != nil || mustExport || len(procs) > 0 || len(onEnd) > 0 {
 			sd := s.makeSpanData()
 			sd.EndTime = internal.MonotonicEndTime(sd.StartTime)
+			if (len(onEnd) > 0 || len(procs) > 0) && sd.Attributes == nil {
+				// Callbacks and processors may add attributes to spans that
+				// have none.
+				sd.Attributes = make(map[string]interface{})
+			}
+			for _, fn := range onEnd {
+				fn(sd)
+			}
+			for _, p := range procs {
+				p.OnEnd(sd)
+			}
+			if len(sd.Attributes) == 0 {
+				sd.Attributes = nil
+			}
 			if s.spanStore != nil {
 				s.spanStore.finished(s, sd)
 			}
 			if mustExport {
-				for e := range exp {
-					e.ExportSpan(sd)
-				}
+				exp.exportSpan(sd)
 			}
 		}
 	})
@@ -419,6 +527,20 @@ func (s *span) AddAttributes(attributes ...Attribute) {
 	s.mu.Unlock()
 }
 
+// OnEnd registers fn to be called when the span ends, after EndTime is set
+// and before the SpanData is handed to exporters. fn may modify the SpanData,
+// for example to add attributes or set the status. Callbacks run in
+// registration order, whether or not the span is exported, and must not call
+// End.
+func (s *span) OnEnd(fn func(*SpanData)) {
+	if !s.IsRecordingEvents() {
+		return
+	}
+	s.mu.Lock()
+	s.onEnd = append(s.onEnd, fn)
+	s.mu.Unlock()
+}
+
 func (s *span) printStringInternal(attributes []Attribute, str string) {
 	now := time.Now()
 	var am map[string]interface{}
@@ -428,6 +550,9 @@ func (s *span) printStringInternal(attributes []Attribute, str string) {
 			am[attr.key] = attr.value
 		}
 	}
+	if max := s.maxAnnotationMessageLength; max > 0 {
+		str = truncateMessage(str, max)
+	}
 	s.mu.Lock()
 	s.annotations.add(Annotation{
 		Time:       now,
@@ -437,6 +562,22 @@ func (s *span) printStringInternal(attributes []Attribute, str string) {
 	s.mu.Unlock()
 }
 
+// truncatedMarker is appended to annotation messages longer than
+// Config.MaxAnnotationMessageLength.
+const truncatedMarker = "..."
+
+// truncateMessage returns str cut to at most max bytes, without splitting a
+// UTF-8 encoded rune, followed by truncatedMarker if str is longer than max.
+func truncateMessage(str string, max int) string {
+	if len(str) <= max {
+		return str
+	}
+	for max > 0 && !utf8.RuneStart(str[max]) {
+		max--
+	}
+	return str[:max] + truncatedMarker
+}
+
 // Annotate adds an annotation with attributes.
 // Attributes can be nil.
 func (s *span) Annotate(attributes []Attribute, str string) {
@@ -531,6 +672,10 @@ func init() {
 		MaxAnnotationEventsPerSpan: DefaultMaxAnnotationEventsPerSpan,
 		MaxMessageEventsPerSpan:    DefaultMaxMessageEventsPerSpan,
 		MaxLinksPerSpan:            DefaultMaxLinksPerSpan,
+		MaxExportBatchSize:         DefaultMaxExportBatchSize,
+		ExportBatchInterval:        DefaultExportBatchInterval,
+		MaxSpansPerSpanStoreBucket: DefaultMaxSpansPerSpanStoreBucket,
+		TraceIDBits:                128,
 	})
 }
 
@@ -584,11 +729,19 @@ func (gen *defaultIDGenerator) NewSpanID() [8]byte {
 // NewTraceID returns a non-zero trace ID from a randomly-chosen sequence.
 // mu should be held while this function is called.
 func (gen *defaultIDGenerator) NewTraceID() [16]byte {
+	return gen.newTraceID(128)
+}
+
+// newTraceID is like NewTraceID, but zeroes the high 8 bytes of the trace
+// ID if bits is 64, as set by Config.TraceIDBits.
+func (gen *defaultIDGenerator) newTraceID(bits int) [16]byte {
 	var tid [16]byte
 	// Construct the trace ID from two outputs of traceIDRand, with a constant
 	// added to each half for additional entropy.
 	gen.Lock()
-	binary.LittleEndian.PutUint64(tid[0:8], gen.traceIDRand.Uint64()+gen.traceIDAdd[0])
+	if bits != 64 {
+		binary.LittleEndian.PutUint64(tid[0:8], gen.traceIDRand.Uint64()+gen.traceIDAdd[0])
+	}
 	binary.LittleEndian.PutUint64(tid[8:16], gen.traceIDRand.Uint64()+gen.traceIDAdd[1])
 	gen.Unlock()
 	return tid
@@ -73,11 +73,55 @@ func FromContext(ctx context.Context) *Span {
 	return DefaultTracer.FromContext(ctx)
 }
 
+// AttributesEnabled reports whether the span in ctx is recording events, in
+// which case attributes, annotations and message events added to it are
+// kept. Hot paths can use it to skip building attributes that would be
+// dropped:
+//
+//	if trace.AttributesEnabled(ctx) {
+//		trace.FromContext(ctx).AddAttributes(trace.StringAttribute("key", value))
+//	}
+func AttributesEnabled(ctx context.Context) bool {
+	return FromContext(ctx).IsRecordingEvents()
+}
+
+// SetSpanName renames the span in ctx, if it is recording events. It is
+// useful when the name of the operation is only known after the span has
+// started, such as after routing a request.
+func SetSpanName(ctx context.Context, name string) {
+	FromContext(ctx).SetName(name)
+}
+
+// LinkFromContext returns a link of the given type to the span in ctx, for
+// example to link a span to the span that enqueued the message it
+// processes. It returns false if ctx has no span.
+func LinkFromContext(ctx context.Context, linkType LinkType) (Link, bool) {
+	sc := FromContext(ctx).SpanContext()
+	if sc == (SpanContext{}) {
+		return Link{}, false
+	}
+	return Link{TraceID: sc.TraceID, SpanID: sc.SpanID, Type: linkType}, true
+}
+
 // NewContext returns a new context with the given Span attached.
 func NewContext(parent context.Context, s *Span) context.Context {
 	return DefaultTracer.NewContext(parent, s)
 }
 
+// Detach returns a context carrying the span of ctx, if any, but none of its
+// values, deadline or cancellation. It is meant for work outliving the
+// operation of ctx, such as a goroutine started by a request handler, whose
+// spans should still be children of the span of ctx:
+//
+//	go process(trace.Detach(ctx))
+func Detach(ctx context.Context) context.Context {
+	detached := context.Background()
+	if s := FromContext(ctx); s.SpanContext() != (SpanContext{}) {
+		detached = NewContext(detached, s)
+	}
+	return detached
+}
+
 // SpanInterface represents a span of a trace.  It has an associated SpanContext, and
 // stores data accumulated while the span is active.
 //
@@ -256,6 +300,52 @@ func (s *Span) AddLink(l Link) {
 	s.internal.AddLink(l)
 }
 
+// LinkTo adds a link of the given type to the span identified by sc, which
+// may belong to another trace. It is useful in fan-in and fan-out patterns,
+// such as processing messages from a queue, where the related span cannot
+// be the parent.
+func (s *Span) LinkTo(sc SpanContext, linkType LinkType, attributes ...Attribute) {
+	if !s.IsRecordingEvents() {
+		return
+	}
+	var am map[string]interface{}
+	if len(attributes) != 0 {
+		am = make(map[string]interface{}, len(attributes))
+		for _, attr := range attributes {
+			am[attr.key] = attr.value
+		}
+	}
+	s.internal.AddLink(Link{
+		TraceID:    sc.TraceID,
+		SpanID:     sc.SpanID,
+		Type:       linkType,
+		Attributes: am,
+	})
+}
+
+// OnEnd registers fn to be called when the span ends, after EndTime is set
+// and before the SpanData is handed to exporters. fn may modify the SpanData,
+// for example to add attributes or set the status. Callbacks run in
+// registration order, whether or not the span is exported, and must not call
+// End.
+//
+// OnEnd has no effect if the span is not recording events, or if the
+// underlying SpanInterface does not support end callbacks.
+func (s *Span) OnEnd(fn func(*SpanData)) {
+	if !s.IsRecordingEvents() {
+		return
+	}
+	if oe, ok := s.internal.(onEnder); ok {
+		oe.OnEnd(fn)
+	}
+}
+
+// onEnder is implemented by SpanInterface implementations that support
+// OnEnd callbacks.
+type onEnder interface {
+	OnEnd(fn func(*SpanData))
+}
+
 // String prints a string representation of a span.
 func (s *Span) String() string {
 	if s == nil {
@@ -16,12 +16,18 @@ package trace
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"reflect"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.opencensus.io/internal"
 	"go.opencensus.io/trace/tracestate"
 )
 
@@ -192,6 +198,184 @@ func TestProbabilitySampler(t *testing.T) {
 	}
 }
 
+func TestProbabilitySamplerLowTraceIDBytes(t *testing.T) {
+	sampler := ProbabilitySampler(0.3)
+	r := rand.New(rand.NewSource(1))
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		// Only the low 8 bytes are populated, as with 64-bit trace IDs.
+		var traceID TraceID
+		binary.BigEndian.PutUint64(traceID[8:], r.Uint64())
+		if sampler(SamplingParameters{TraceID: traceID}).Sample {
+			sampled++
+		}
+	}
+	if sampled < 200 || sampled > 400 {
+		t.Errorf("got %f%% sampled trace IDs, want approximately 30%%", float64(sampled)*0.1)
+	}
+}
+
+func TestParentBased(t *testing.T) {
+	sampler := ParentBased(ProbabilitySampler(0.3))
+	parent := SpanContext{TraceID: tid, SpanID: sid}
+
+	if sampler(SamplingParameters{ParentContext: parent, TraceID: tid}).Sample {
+		t.Error("unsampled parent: got sampled; want not sampled")
+	}
+	parent.TraceOptions = 1
+	if !sampler(SamplingParameters{ParentContext: parent, TraceID: tid}).Sample {
+		t.Error("sampled parent: got not sampled; want sampled")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	sampled := 0
+	for i := 0; i < 1000; i++ {
+		var traceID TraceID
+		r.Read(traceID[:])
+		if sampler(SamplingParameters{TraceID: traceID}).Sample {
+			sampled++
+		}
+	}
+	if sampled < 200 || sampled > 400 {
+		t.Errorf("no parent: got %f%% sampled trace IDs, want approximately 30%%", float64(sampled)*0.1)
+	}
+
+	if fraction, ok := SamplerFraction(sampler); fraction != 0.3 || !ok {
+		t.Errorf("SamplerFraction() = %v, %v; want 0.3, true", fraction, ok)
+	}
+}
+
+func TestCompositeSamplers(t *testing.T) {
+	var calls []string
+	mock := func(name string, d SamplingDecision) Sampler {
+		return func(SamplingParameters) SamplingDecision {
+			calls = append(calls, name)
+			return d
+		}
+	}
+	yes := SamplingDecision{Sample: true}
+	no := SamplingDecision{}
+	record := SamplingDecision{RecordOnly: true}
+
+	tests := []struct {
+		name      string
+		sampler   Sampler
+		want      SamplingDecision
+		wantCalls []string
+	}{
+		{"all: all sample", AllSamplers(mock("a", yes), mock("b", yes)), yes, []string{"a", "b"}},
+		{"all: first refuses", AllSamplers(mock("a", no), mock("b", yes)), no, []string{"a"}},
+		{"all: last refuses", AllSamplers(mock("a", yes), mock("b", no)), no, []string{"a", "b"}},
+		{"all: one records", AllSamplers(mock("a", record), mock("b", yes)), record, []string{"a", "b"}},
+		{"all: empty", AllSamplers(), yes, nil},
+		{"any: none sample", AnySampler(mock("a", no), mock("b", no)), no, []string{"a", "b"}},
+		{"any: first samples", AnySampler(mock("a", yes), mock("b", no)), yes, []string{"a"}},
+		{"any: last samples", AnySampler(mock("a", no), mock("b", yes)), yes, []string{"a", "b"}},
+		{"any: one records", AnySampler(mock("a", record), mock("b", no)), record, []string{"a", "b"}},
+		{"any: empty", AnySampler(), no, nil},
+		{"nested", AnySampler(AllSamplers(mock("a", yes), mock("b", no)), mock("c", yes)), yes, []string{"a", "b", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			if got := tt.sampler(SamplingParameters{TraceID: tid}); got != tt.want {
+				t.Errorf("decision = %+v; want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("samplers called = %v; want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+
+	// Composite samplers compose with ParentBased.
+	sampler := ParentBased(AllSamplers(AlwaysSample(), NeverSample()))
+	parent := SpanContext{TraceID: tid, SpanID: sid, TraceOptions: 1}
+	if !sampler(SamplingParameters{ParentContext: parent, TraceID: tid}).Sample {
+		t.Error("sampled parent: got not sampled; want sampled")
+	}
+	if sampler(SamplingParameters{TraceID: tid}).Sample {
+		t.Error("root span: got sampled; want not sampled")
+	}
+	if _, ok := SamplerFraction(AllSamplers(AlwaysSample())); ok {
+		t.Error("SamplerFraction(AllSamplers()) ok = true; want false")
+	}
+}
+
+func TestNameBasedSampler(t *testing.T) {
+	rules := map[string]Sampler{"/healthz": NeverSample()}
+	sampler := NameBasedSampler(rules, AlwaysSample())
+	rules["/other"] = NeverSample() // not seen by the sampler
+
+	for name, want := range map[string]bool{
+		"/healthz":  false,
+		"/healthz/": true,
+		"/other":    true,
+		"":          true,
+	} {
+		if got := sampler(SamplingParameters{TraceID: tid, Name: name}).Sample; got != want {
+			t.Errorf("span %q: sampled = %v; want %v", name, got, want)
+		}
+	}
+
+	ApplyConfig(Config{DefaultSampler: sampler})
+	defer ApplyConfig(Config{DefaultSampler: ProbabilitySampler(defaultSamplingProbability)})
+	_, health := StartSpan(context.Background(), "/healthz")
+	_, other := StartSpan(context.Background(), "/users")
+	if health.SpanContext().IsSampled() {
+		t.Error("/healthz span is sampled; want not sampled")
+	}
+	if !other.SpanContext().IsSampled() {
+		t.Error("/users span is not sampled; want sampled")
+	}
+
+	if f, ok := SamplerFraction(sampler); !ok || f != 1 {
+		t.Errorf("SamplerFraction() = %v, %v; want 1, true", f, ok)
+	}
+}
+
+func TestSamplerFraction(t *testing.T) {
+	tests := []struct {
+		name         string
+		sampler      Sampler
+		wantFraction float64
+		wantOk       bool
+	}{
+		{"probability", ProbabilitySampler(0.25), 0.25, true},
+		{"negative probability", ProbabilitySampler(-1), 0, true},
+		{"probability above one", ProbabilitySampler(2), 1, true},
+		{"always", AlwaysSample(), 1, true},
+		{"never", NeverSample(), 0, true},
+		{"custom", func(SamplingParameters) SamplingDecision { return SamplingDecision{Sample: true} }, 0, false},
+	}
+	for _, tt := range tests {
+		fraction, ok := SamplerFraction(tt.sampler)
+		if fraction != tt.wantFraction || ok != tt.wantOk {
+			t.Errorf("%s: SamplerFraction() = %v, %v; want %v, %v", tt.name, fraction, ok, tt.wantFraction, tt.wantOk)
+		}
+	}
+
+	// Samplers created elsewhere may have side effects, so they are not called.
+	calls := 0
+	counting := Sampler(func(SamplingParameters) SamplingDecision {
+		calls++
+		return SamplingDecision{}
+	})
+	for _, s := range []Sampler{
+		counting,
+		ParentBased(counting),
+		NameBasedSampler(map[string]Sampler{"a": AlwaysSample()}, counting),
+		AllSamplers(AlwaysSample(), counting),
+		AnySampler(NeverSample(), counting),
+	} {
+		if _, ok := SamplerFraction(s); ok {
+			t.Error("SamplerFraction() ok = true for a custom sampler; want false")
+		}
+	}
+	if calls != 0 {
+		t.Errorf("SamplerFraction() called a custom sampler %d times; want 0", calls)
+	}
+}
+
 func TestStartSpanWithRemoteParent(t *testing.T) {
 	sc := SpanContext{
 		TraceID:      tid,
@@ -363,6 +547,124 @@ func TestSpanKind(t *testing.T) {
 	}
 }
 
+func TestStartSpanWithSpanKind(t *testing.T) {
+	te := &testExporter{}
+	RegisterExporter(te)
+	defer UnregisterExporter(te)
+
+	ctx, parent := StartSpan(context.Background(), "parent", WithSampler(AlwaysSample()), WithSpanKind(SpanKindServer))
+	_, child := StartSpan(ctx, "child", WithSpanKind(SpanKindClient))
+	child.End()
+	parent.End()
+
+	if len(te.spans) != 2 {
+		t.Fatalf("got %d exported spans; want 2", len(te.spans))
+	}
+	for i, want := range []int{SpanKindClient, SpanKindServer} {
+		if got := te.spans[i].SpanKind; got != want {
+			t.Errorf("%s: SpanKind = %d; want %d", te.spans[i].Name, got, want)
+		}
+	}
+}
+
+func TestAttributesEnabled(t *testing.T) {
+	if AttributesEnabled(context.Background()) {
+		t.Error("AttributesEnabled() without a span = true; want false")
+	}
+	ctx, span := StartSpan(context.Background(), "span", WithSampler(NeverSample()))
+	if AttributesEnabled(ctx) {
+		t.Error("AttributesEnabled() for an unsampled span = true; want false")
+	}
+	span.End()
+	ctx, span = StartSpan(context.Background(), "span", WithSampler(AlwaysSample()))
+	if !AttributesEnabled(ctx) {
+		t.Error("AttributesEnabled() for a sampled span = false; want true")
+	}
+	span.End()
+}
+
+func TestRecordOnlySampler(t *testing.T) {
+	enabled := internal.LocalSpanStoreEnabled
+	internal.LocalSpanStoreEnabled = true
+	defer func() { internal.LocalSpanStoreEnabled = enabled }()
+
+	te := &testExporter{}
+	RegisterExporter(te)
+	defer UnregisterExporter(te)
+
+	recordOnly := func(SamplingParameters) SamplingDecision {
+		return SamplingDecision{RecordOnly: true}
+	}
+	name := "TestRecordOnlySampler"
+	_, span := StartSpan(context.Background(), name, WithSampler(recordOnly))
+	if span.SpanContext().IsSampled() {
+		t.Error("RecordOnly span is sampled")
+	}
+	if !span.IsRecordingEvents() {
+		t.Error("RecordOnly span is not recording events")
+	}
+	if got := len(internalOnly{}.ReportActiveSpans(name)); got != 1 {
+		t.Errorf("got %d active spans in the local store; want 1", got)
+	}
+	span.End()
+
+	finished := 0
+	for _, b := range (internalOnly{}).ReportSpansPerMethod()[name].LatencyBuckets {
+		finished += b.Size
+	}
+	if finished != 1 {
+		t.Errorf("got %d finished spans in the local store; want 1", finished)
+	}
+	if len(te.spans) != 0 {
+		t.Errorf("RecordOnly span was exported: %v", te.spans)
+	}
+}
+
+func TestSetSpanStoreSize(t *testing.T) {
+	name := "TestSetSpanStoreSize"
+	SetSpanStoreSize(name, 2)
+	ss := spanStoreForName(name)
+	if ss == nil {
+		t.Fatal("SetSpanStoreSize did not create a span store")
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		// Space out the spans so that none are skipped by bucket sampling.
+		end := start.Add(time.Duration(i) * 2 * samplePeriod)
+		ss.finished(&span{}, &SpanData{
+			Name:      fmt.Sprint(i),
+			StartTime: start,
+			EndTime:   end,
+			Status:    Status{Code: 2},
+		})
+	}
+
+	got := internalOnly{}.ReportSpansByError(name, 2)
+	if len(got) != 2 {
+		t.Fatalf("got %d spans in the error bucket; want 2", len(got))
+	}
+	for _, sd := range got {
+		if sd.Name == "0" {
+			t.Error("oldest span was not evicted")
+		}
+	}
+}
+
+func TestApplyConfigSpanStoreSize(t *testing.T) {
+	cfg := config.Load().(*Config)
+	defer ApplyConfig(*cfg)
+
+	ApplyConfig(Config{MaxSpansPerSpanStoreBucket: 3})
+	ss := spanStoreForNameCreateIfNew("TestApplyConfigSpanStoreSize")
+	if got := len(ss.latency[0].buffer); got != 3 {
+		t.Errorf("got latency bucket size %d; want 3", got)
+	}
+	if got := ss.maxSpansPerErrorBucket; got != 3 {
+		t.Errorf("got error bucket size %d; want 3", got)
+	}
+}
+
 func TestSetSpanAttributes(t *testing.T) {
 	span := startSpan(StartOptions{})
 	span.AddAttributes(StringAttribute("key1", "value1"))
@@ -387,6 +689,40 @@ func TestSetSpanAttributes(t *testing.T) {
 	}
 }
 
+func TestSetSpanAttributeTypes(t *testing.T) {
+	ints := []int64{1, 2}
+	strs := []string{"a", "b"}
+	ApplyConfig(Config{MaxAttributesPerSpan: DefaultMaxAttributesPerSpan})
+	span := startSpan(StartOptions{})
+	span.AddAttributes(
+		Float64Attribute("latency", 1.5),
+		Int64SliceAttribute("ints", ints),
+		StringSliceAttribute("strs", strs),
+	)
+	span.Annotate([]Attribute{Float64Attribute("ratio", 0.25)}, "Annotate")
+	// The attributes must not alias the caller's slices.
+	ints[0], strs[0] = 0, ""
+	got, err := endSpan(span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantAttributes := map[string]interface{}{
+		"latency": 1.5,
+		"ints":    []int64{1, 2},
+		"strs":    []string{"a", "b"},
+	}
+	if !reflect.DeepEqual(got.Attributes, wantAttributes) {
+		t.Errorf("Attributes = %#v; want %#v", got.Attributes, wantAttributes)
+	}
+	if len(got.Annotations) != 1 {
+		t.Fatalf("got %d annotations; want 1", len(got.Annotations))
+	}
+	if got, want := got.Annotations[0].Attributes, map[string]interface{}{"ratio": 0.25}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Annotation attributes = %#v; want %#v", got, want)
+	}
+}
+
 func TestSetSpanAttributesOverLimit(t *testing.T) {
 	cfg := Config{MaxAttributesPerSpan: 2}
 	ApplyConfig(cfg)
@@ -491,6 +827,30 @@ func TestAnnotationsOverLimit(t *testing.T) {
 	}
 }
 
+func TestAnnotationMessageLength(t *testing.T) {
+	ApplyConfig(Config{MaxAnnotationMessageLength: 8, MaxAnnotationEventsPerSpan: DefaultMaxAnnotationEventsPerSpan})
+	defer ApplyConfig(Config{MaxAnnotationMessageLength: -1})
+
+	span := startSpan(StartOptions{})
+	span.Annotate(nil, "12345678")
+	span.Annotate(nil, "123456789")
+	span.Annotatef(nil, "SELECT %s FROM t", "x")
+	span.Annotate(nil, "1234567é") // the last rune straddles the limit
+	got, err := endSpan(span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var msgs []string
+	for _, a := range got.Annotations {
+		msgs = append(msgs, a.Message)
+	}
+	want := []string{"12345678", "12345678...", "SELECT x...", "1234567..."}
+	if !reflect.DeepEqual(msgs, want) {
+		t.Errorf("annotation messages = %q; want %q", msgs, want)
+	}
+}
+
 func TestMessageEvents(t *testing.T) {
 	span := startSpan(StartOptions{})
 	span.AddMessageReceiveEvent(3, 400, 300)
@@ -578,6 +938,48 @@ func TestSetSpanName(t *testing.T) {
 	}
 }
 
+func TestDetach(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, span := StartSpan(ctx, "parent", WithSampler(AlwaysSample()))
+	defer span.End()
+	detached := Detach(ctx)
+	cancel()
+
+	if detached.Err() != nil {
+		t.Errorf("detached context was canceled with its source: %v", detached.Err())
+	}
+	if _, ok := detached.Deadline(); ok {
+		t.Error("detached context has a deadline")
+	}
+	if got := FromContext(detached); got != span {
+		t.Errorf("FromContext(detached) = %v; want %v", got, span)
+	}
+	_, child := StartSpan(detached, "child")
+	if got, want := child.SpanContext().TraceID, span.SpanContext().TraceID; got != want {
+		t.Errorf("child of detached context has trace ID %v; want %v", got, want)
+	}
+
+	if got := FromContext(Detach(context.Background())); got.SpanContext() != (SpanContext{}) {
+		t.Errorf("Detach of a context without span has span %v", got)
+	}
+}
+
+func TestSetSpanNameFromContext(t *testing.T) {
+	want := "SpanName-ctx"
+	span := startSpan(StartOptions{})
+	SetSpanName(NewContext(context.Background(), span), want)
+	// No span in the context is a no-op.
+	SetSpanName(context.Background(), "NoopName")
+	got, err := endSpan(span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Name != want {
+		t.Errorf("span.Name=%q; want %q", got.Name, want)
+	}
+}
+
 func TestSetSpanNameUnsampledSpan(t *testing.T) {
 	var nilSpanData *SpanData
 	s := startSpan(StartOptions{Sampler: NeverSample()})
@@ -639,6 +1041,70 @@ func TestSetSpanStatus(t *testing.T) {
 	}
 }
 
+func TestOnEnd(t *testing.T) {
+	span := startSpan(StartOptions{})
+	var order []int
+	span.OnEnd(func(sd *SpanData) {
+		order = append(order, 1)
+		if sd.EndTime.IsZero() {
+			t.Error("OnEnd callback called before EndTime was set")
+		}
+		sd.Status = Status{Code: 1, Message: "request failed"}
+	})
+	span.OnEnd(func(sd *SpanData) {
+		order = append(order, 2)
+		sd.Attributes = map[string]interface{}{"key1": "value1"}
+	})
+	got, err := endSpan(span)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2}; !reflect.DeepEqual(order, want) {
+		t.Errorf("OnEnd callbacks called in order %v, want %v", order, want)
+	}
+
+	want := &SpanData{
+		SpanContext: SpanContext{
+			TraceID:      tid,
+			SpanID:       SpanID{},
+			TraceOptions: 0x1,
+		},
+		ParentSpanID:    sid,
+		Name:            "span0",
+		Attributes:      map[string]interface{}{"key1": "value1"},
+		Status:          Status{Code: 1, Message: "request failed"},
+		HasRemoteParent: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("exporting span: got %#v want %#v", got, want)
+	}
+}
+
+func TestOnEndWithoutExporter(t *testing.T) {
+	// No exporter, span store or processor is registered, and the span has
+	// no attributes.
+	_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+	var got map[string]interface{}
+	span.OnEnd(func(sd *SpanData) {
+		sd.Attributes["key1"] = "value1"
+		got = sd.Attributes
+	})
+	span.End()
+	if want := map[string]interface{}{"key1": "value1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("OnEnd callback saw Attributes %v; want %v", got, want)
+	}
+}
+
+func TestOnEndUnsampledSpan(t *testing.T) {
+	_, span := StartSpan(context.Background(), "foo", WithSampler(NeverSample()))
+	called := false
+	span.OnEnd(func(*SpanData) { called = true })
+	span.End()
+	if called {
+		t.Error("OnEnd callback called for a span that is not recording events")
+	}
+}
+
 func TestAddLink(t *testing.T) {
 	span := startSpan(StartOptions{})
 	span.AddLink(Link{
@@ -673,6 +1139,47 @@ func TestAddLink(t *testing.T) {
 	}
 }
 
+func TestLinkTo(t *testing.T) {
+	otherTID := TraceID{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+	otherSID := SpanID{8, 7, 6, 5, 4, 3, 2, 1}
+	ctx, producer := StartSpan(context.Background(), "producer", WithSampler(AlwaysSample()))
+	producer.End()
+
+	span := startSpan(StartOptions{})
+	span.LinkTo(SpanContext{TraceID: otherTID, SpanID: otherSID}, LinkTypeChild, StringAttribute("key", "value"))
+	l, ok := LinkFromContext(ctx, LinkTypeParent)
+	if !ok {
+		t.Fatal("LinkFromContext() = false; want true")
+	}
+	span.AddLink(l)
+	got, err := endSpan(span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	psc := producer.SpanContext()
+	want := []Link{
+		{
+			TraceID:    otherTID,
+			SpanID:     otherSID,
+			Type:       LinkTypeChild,
+			Attributes: map[string]interface{}{"key": "value"},
+		},
+		{
+			TraceID: psc.TraceID,
+			SpanID:  psc.SpanID,
+			Type:    LinkTypeParent,
+		},
+	}
+	if !reflect.DeepEqual(got.Links, want) {
+		t.Errorf("Links = %#v; want %#v", got.Links, want)
+	}
+
+	if _, ok := LinkFromContext(context.Background(), LinkTypeParent); ok {
+		t.Error("LinkFromContext() without a span = true; want false")
+	}
+}
+
 func TestAddLinkOverLimit(t *testing.T) {
 	cfg := Config{MaxLinksPerSpan: 1}
 	ApplyConfig(cfg)
@@ -728,6 +1235,295 @@ func TestUnregisterExporter(t *testing.T) {
 	}
 }
 
+func TestRegisterExporterTwice(t *testing.T) {
+	var te testExporter
+	RegisterExporter(&te)
+	RegisterExporter(&te)
+
+	_, span := StartSpan(context.Background(), "span", WithSampler(AlwaysSample()))
+	span.End()
+	if got := len(te.spans); got != 1 {
+		t.Errorf("exporter registered twice was called %d times; want 1", got)
+	}
+
+	UnregisterExporter(&te)
+	_, span = StartSpan(context.Background(), "span", WithSampler(AlwaysSample()))
+	span.End()
+	if got := len(te.spans); got != 2 {
+		t.Errorf("exporter unregistered once of two registrations was called %d times in total; want 2", got)
+	}
+
+	UnregisterExporter(&te)
+	_, span = StartSpan(context.Background(), "span", WithSampler(AlwaysSample()))
+	span.End()
+	if got := len(te.spans); got != 2 {
+		t.Errorf("unregistered exporter was called %d times in total; want 2", got)
+	}
+}
+
+type testBatchExporter struct {
+	mu      sync.Mutex
+	batches [][]*SpanData
+}
+
+func (t *testBatchExporter) ExportSpan(s *SpanData) {
+	t.ExportSpans([]*SpanData{s})
+}
+
+func (t *testBatchExporter) ExportSpans(sds []*SpanData) {
+	t.mu.Lock()
+	t.batches = append(t.batches, sds)
+	t.mu.Unlock()
+}
+
+func TestBatchExporter(t *testing.T) {
+	ApplyConfig(Config{MaxExportBatchSize: 100, ExportBatchInterval: time.Hour})
+	defer ApplyConfig(Config{
+		MaxExportBatchSize:  DefaultMaxExportBatchSize,
+		ExportBatchInterval: DefaultExportBatchInterval,
+	})
+
+	var te testBatchExporter
+	RegisterExporter(&te)
+	for i := 0; i < 250; i++ {
+		_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+		span.End()
+	}
+	// Unregistering flushes the spans still pending.
+	UnregisterExporter(&te)
+
+	var got []int
+	for _, b := range te.batches {
+		got = append(got, len(b))
+	}
+	if want := []int{100, 100, 50}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got batch sizes %v, want %v", got, want)
+	}
+}
+
+func TestBatchExporterInterval(t *testing.T) {
+	ApplyConfig(Config{ExportBatchInterval: 10 * time.Millisecond})
+	defer ApplyConfig(Config{ExportBatchInterval: DefaultExportBatchInterval})
+
+	var te testBatchExporter
+	RegisterExporter(&te)
+	defer UnregisterExporter(&te)
+	_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+	span.End()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		te.mu.Lock()
+		n := len(te.batches)
+		te.mu.Unlock()
+		if n == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("pending span was not exported after the batch interval")
+}
+
+type blockingBatchExporter struct {
+	testBatchExporter
+	release chan struct{}
+}
+
+func (e *blockingBatchExporter) ExportSpans(sds []*SpanData) {
+	<-e.release
+	e.testBatchExporter.ExportSpans(sds)
+}
+
+func TestBatchExporterDoesNotBlockEnd(t *testing.T) {
+	ApplyConfig(Config{MaxExportBatchSize: 1, ExportBatchInterval: time.Hour})
+	defer ApplyConfig(Config{
+		MaxExportBatchSize:  DefaultMaxExportBatchSize,
+		ExportBatchInterval: DefaultExportBatchInterval,
+	})
+
+	e := &blockingBatchExporter{release: make(chan struct{})}
+	RegisterExporter(e)
+	ended := make(chan struct{})
+	go func() {
+		// Every span fills a batch, but the exporter is blocked.
+		for i := 0; i < 3; i++ {
+			_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+			span.End()
+		}
+		close(ended)
+	}()
+	select {
+	case <-ended:
+	case <-time.After(5 * time.Second):
+		t.Fatal("End blocked on a full batch")
+	}
+	close(e.release)
+	UnregisterExporter(e)
+	if got := len(e.batches); got != 3 {
+		t.Errorf("got %d batches; want 3", got)
+	}
+}
+
+func TestSpanBatcherAddAfterStop(t *testing.T) {
+	var te testBatchExporter
+	b := newSpanBatcher(&te, 10, time.Hour)
+	b.add(&SpanData{Name: "before"})
+	b.stop()
+	// An End that loaded the exporters before the batcher was stopped.
+	b.add(&SpanData{Name: "after"})
+	if len(b.spans) != 0 {
+		t.Errorf("%d spans pending after stop; want them dropped", len(b.spans))
+	}
+	if len(te.batches) != 1 || len(te.batches[0]) != 1 || te.batches[0][0].Name != "before" {
+		t.Errorf("exported %v; want only the span added before stop", te.batches)
+	}
+}
+
+type failingBatchExporter struct {
+	testBatchExporter
+	err error
+}
+
+func (e *failingBatchExporter) ExportSpansWithError(sds []*SpanData) error {
+	return e.err
+}
+
+func TestExportErrorHandler(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	SetExportErrorHandler(func(err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+	defer SetExportErrorHandler(nil)
+
+	e := &failingBatchExporter{err: errors.New("backend unavailable")}
+	RegisterExporter(e)
+	before := ExportStats()
+	_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+	span.End()
+	// Unregistering exports the pending batch.
+	UnregisterExporter(e)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 1 || errs[0] != e.err {
+		t.Errorf("got errors %v; want [%v]", errs, e.err)
+	}
+	if got := ExportStats().SpansExported - before.SpansExported; got != 0 {
+		t.Errorf("SpansExported increased by %d after a failed export; want 0", got)
+	}
+	if len(e.batches) != 0 {
+		t.Errorf("ExportSpans was called %d times; want ExportSpansWithError to be used", len(e.batches))
+	}
+}
+
+type blockingExporter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (e *blockingExporter) ExportSpan(sd *SpanData) {
+	select {
+	case e.started <- struct{}{}:
+	default:
+	}
+	<-e.release
+}
+
+func TestExportQueueDropsSpans(t *testing.T) {
+	ApplyConfig(Config{ExportQueueSize: 2})
+	defer ApplyConfig(Config{ExportQueueSize: -1})
+
+	e := &blockingExporter{started: make(chan struct{}, 1), release: make(chan struct{})}
+	RegisterExporter(e)
+	before := ExportStats()
+	end := func() {
+		_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+		span.End()
+	}
+
+	// The first span blocks the exporter, the next two fill the queue and
+	// the last two are dropped. End must not block meanwhile.
+	end()
+	<-e.started
+	for i := 0; i < 4; i++ {
+		end()
+	}
+	if got := ExportStats().SpansDropped - before.SpansDropped; got != 2 {
+		t.Errorf("SpansDropped increased by %d; want 2", got)
+	}
+
+	close(e.release)
+	// Unregistering exports the queued spans.
+	UnregisterExporter(e)
+	if got := ExportStats().SpansExported - before.SpansExported; got != 3 {
+		t.Errorf("SpansExported increased by %d; want 3", got)
+	}
+}
+
+type flushExporter struct {
+	testBatchExporter
+	flushed bool
+	block   bool
+}
+
+func (e *flushExporter) Flush(ctx context.Context) error {
+	if e.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	e.flushed = true
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	ApplyConfig(Config{MaxExportBatchSize: 100, ExportBatchInterval: time.Hour})
+	defer ApplyConfig(Config{
+		MaxExportBatchSize:  DefaultMaxExportBatchSize,
+		ExportBatchInterval: DefaultExportBatchInterval,
+	})
+
+	e := &flushExporter{}
+	var plain testExporter
+	RegisterExporter(e)
+	RegisterExporter(&plain)
+	_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+	span.End()
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() = %v", err)
+	}
+	if !e.flushed {
+		t.Error("Flush was not called")
+	}
+	if len(e.batches) != 1 {
+		t.Errorf("got %d batches; want the pending batch exported before Flush", len(e.batches))
+	}
+	if len(plain.spans) != 1 {
+		t.Errorf("got %d spans in the exporter without Flush; want 1", len(plain.spans))
+	}
+
+	// Exporters are unregistered.
+	_, span = StartSpan(context.Background(), "bar", WithSampler(AlwaysSample()))
+	span.End()
+	if len(plain.spans) != 1 {
+		t.Errorf("span exported after Shutdown")
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	RegisterExporter(&flushExporter{block: true})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown() = %v; want %v", err, context.DeadlineExceeded)
+	}
+}
+
 func TestBucket(t *testing.T) {
 	// make a bucket of size 5 and add 10 spans
 	b := makeBucket(5)
@@ -861,6 +1657,31 @@ func TestChildSpanCount(t *testing.T) {
 	}
 }
 
+func TestChildSpanCountConcurrent(t *testing.T) {
+	spans := make(exporter)
+	RegisterExporter(&spans)
+	defer UnregisterExporter(&spans)
+	ctx, parent := StartSpan(context.Background(), "parent", WithSampler(AlwaysSample()))
+	children := make([]*Span, 3)
+	var wg sync.WaitGroup
+	for i := range children {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, children[i] = StartSpan(ctx, "child", WithSampler(AlwaysSample()))
+		}(i)
+	}
+	wg.Wait()
+	for _, child := range children {
+		child.End()
+	}
+	parent.End()
+	UnregisterExporter(&spans)
+	if got, want := spans["parent"].ChildSpanCount, 3; got != want {
+		t.Errorf("parent.ChildSpanCount=%d; want %d", got, want)
+	}
+}
+
 func TestNilSpanEnd(t *testing.T) {
 	var span *Span
 	span.End()
@@ -899,3 +1720,241 @@ func TestExecutionTracerTaskEnd(t *testing.T) {
 		t.Fatalf("Execution tracer task ended for %v spans; want %v", got, want)
 	}
 }
+
+type stampProcessor struct {
+	mu    sync.Mutex
+	ended []string
+}
+
+func (p *stampProcessor) OnStart(sd *SpanData) {
+	sd.Attributes["stamp"] = "started:" + sd.Name
+}
+
+func (p *stampProcessor) OnEnd(sd *SpanData) {
+	p.mu.Lock()
+	p.ended = append(p.ended, sd.Name)
+	p.mu.Unlock()
+}
+
+func TestSpanProcessor(t *testing.T) {
+	p := &stampProcessor{}
+	RegisterSpanProcessor(p)
+	var te testExporter
+	RegisterExporter(&te)
+	defer UnregisterExporter(&te)
+
+	_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+	span.End()
+	_, span = StartSpan(context.Background(), "unsampled", WithSampler(NeverSample()))
+	span.End()
+
+	UnregisterSpanProcessor(p)
+	_, span = StartSpan(context.Background(), "bar", WithSampler(AlwaysSample()))
+	span.End()
+
+	if len(te.spans) != 2 {
+		t.Fatalf("got %d exported spans; want 2", len(te.spans))
+	}
+	if got, want := te.spans[0].Attributes["stamp"], "started:foo"; got != want {
+		t.Errorf("stamp attribute = %v; want %q", got, want)
+	}
+	if got, ok := te.spans[1].Attributes["stamp"]; ok {
+		t.Errorf("stamp attribute = %v after UnregisterSpanProcessor; want none", got)
+	}
+	// Unsampled spans are not recording events, so processors are not called.
+	if want := []string{"foo"}; !reflect.DeepEqual(p.ended, want) {
+		t.Errorf("OnEnd called for %v; want %v", p.ended, want)
+	}
+}
+
+type endAttributeProcessor struct{}
+
+func (endAttributeProcessor) OnStart(sd *SpanData) {}
+
+func (endAttributeProcessor) OnEnd(sd *SpanData) {
+	sd.Attributes["ended"] = true
+}
+
+func TestSpanProcessorOnEndAddsAttributes(t *testing.T) {
+	var p endAttributeProcessor
+	RegisterSpanProcessor(p)
+	defer UnregisterSpanProcessor(p)
+	var te testExporter
+	RegisterExporter(&te)
+	defer UnregisterExporter(&te)
+
+	// The span has no attributes, so OnEnd must not see a nil map.
+	_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+	span.End()
+
+	if len(te.spans) != 1 {
+		t.Fatalf("got %d exported spans; want 1", len(te.spans))
+	}
+	if got, want := te.spans[0].Attributes, map[string]interface{}{"ended": true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Attributes = %v; want %v", got, want)
+	}
+}
+
+func TestExporterProcessor(t *testing.T) {
+	var te testExporter
+	p := ExporterProcessor(&te)
+	RegisterSpanProcessor(p)
+	defer UnregisterSpanProcessor(p)
+
+	_, span := StartSpan(context.Background(), "foo", WithSampler(AlwaysSample()))
+	span.End()
+	_, span = StartSpan(context.Background(), "record-only", WithSampler(func(SamplingParameters) SamplingDecision {
+		return SamplingDecision{RecordOnly: true}
+	}))
+	span.End()
+
+	if len(te.spans) != 1 || te.spans[0].Name != "foo" {
+		t.Errorf("exported %v; want only the sampled span", te.spans)
+	}
+}
+
+func TestSpanContextText(t *testing.T) {
+	sampled := SpanContext{
+		TraceID:      TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:       SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceOptions: 1,
+	}
+	unsampled := sampled
+	unsampled.TraceOptions = 0
+
+	for _, tt := range []struct {
+		sc   SpanContext
+		text string
+	}{
+		{sampled, "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		{unsampled, "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+	} {
+		b, err := tt.sc.MarshalText()
+		if err != nil || string(b) != tt.text {
+			t.Errorf("MarshalText() = %q, %v; want %q", b, err, tt.text)
+		}
+		var got SpanContext
+		if err := got.UnmarshalText(b); err != nil || got != tt.sc {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want %v", b, got, err, tt.sc)
+		}
+	}
+
+	if n := testing.AllocsPerRun(10, func() { sampled.MarshalText() }); n > 1 {
+		t.Errorf("MarshalText allocates %v times; want 1", n)
+	}
+
+	for _, text := range []string{
+		"",
+		"4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-001",
+		"4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7-01",
+		"4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+		"4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bz-01",
+		"4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0x",
+	} {
+		got := sampled
+		if err := got.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) succeeded; want an error", text)
+		}
+		if got != sampled {
+			t.Errorf("UnmarshalText(%q) modified the span context to %v", text, got)
+		}
+	}
+}
+
+func TestSpanDataJSON(t *testing.T) {
+	sd := &SpanData{
+		SpanContext: SpanContext{TraceID: TraceID{1}, SpanID: SpanID{2}, TraceOptions: 1},
+		Name:        "foo",
+		Status:      Status{Code: 2, Message: "bar"},
+	}
+	b, err := json.Marshal(sd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("SpanData encoded as %s, not an object: %v", b, err)
+	}
+	if fields["Name"] != "foo" || fields["TraceOptions"] != 1.0 || fields["Code"] != 2.0 {
+		t.Errorf("SpanData encoded as %s; want its fields", b)
+	}
+
+	var got SpanData
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.SpanContext != sd.SpanContext || got.Name != sd.Name || got.Status != sd.Status {
+		t.Errorf("SpanData decoded as %+v; want %+v", got, *sd)
+	}
+}
+
+// evenFilterExporter exports the spans whose name ends in an even digit.
+type evenFilterExporter struct {
+	testExporter
+}
+
+func (e *evenFilterExporter) ShouldExport(sd *SpanData) bool {
+	return (sd.Name[len(sd.Name)-1]-'0')%2 == 0
+}
+
+func TestExportFilter(t *testing.T) {
+	var all testExporter
+	var half evenFilterExporter
+	RegisterExporter(&all)
+	defer UnregisterExporter(&all)
+	RegisterExporter(&half)
+	defer UnregisterExporter(&half)
+
+	for i := 0; i < 10; i++ {
+		_, span := StartSpan(context.Background(), fmt.Sprintf("span%d", i), WithSampler(AlwaysSample()))
+		span.End()
+	}
+
+	if got := len(all.spans); got != 10 {
+		t.Errorf("exporter without filter got %d spans; want 10", got)
+	}
+	if got := len(half.spans); got != 5 {
+		t.Errorf("filtering exporter got %d spans; want 5", got)
+	}
+	for _, sd := range half.spans {
+		if !half.ShouldExport(sd) {
+			t.Errorf("filtering exporter got span %q", sd.Name)
+		}
+	}
+}
+
+type linkCountingProcessor struct {
+	links int
+}
+
+func (p *linkCountingProcessor) OnStart(sd *SpanData) { p.links = len(sd.Links) }
+
+func (p *linkCountingProcessor) OnEnd(sd *SpanData) {}
+
+func TestStartSpanWithLinks(t *testing.T) {
+	ApplyConfig(Config{MaxLinksPerSpan: DefaultMaxLinksPerSpan})
+	var te testExporter
+	RegisterExporter(&te)
+	defer UnregisterExporter(&te)
+	p := &linkCountingProcessor{}
+	RegisterSpanProcessor(p)
+	defer UnregisterSpanProcessor(p)
+
+	links := []Link{
+		{TraceID: TraceID{1}, SpanID: SpanID{1}, Type: LinkTypeParent},
+		{TraceID: TraceID{2}, SpanID: SpanID{2}, Type: LinkTypeParent, Attributes: map[string]interface{}{"k": "v"}},
+	}
+	_, span := StartSpan(context.Background(), "fan-in", WithSampler(AlwaysSample()), WithLinks(links...))
+	if p.links != 2 {
+		t.Errorf("OnStart saw %d links; want 2", p.links)
+	}
+	span.End()
+
+	if len(te.spans) != 1 {
+		t.Fatalf("got %d exported spans; want 1", len(te.spans))
+	}
+	if got := te.spans[0].Links; !reflect.DeepEqual(got, links) {
+		t.Errorf("Links = %v; want %v", got, links)
+	}
+}
@@ -238,6 +238,21 @@ YKfaD/R0/YH9F/9wiE5uv4BN7L8A/a0BwxxqWzCKPg37b7bdgz8BAAD//6NjPmJCCgAA
 `,
 	},
 
+	"/templates/statsz.html": {
+		local:   "templates/statsz.html",
+		size:    721,
+		modtime: 1760486400,
+		compressed: `
+H4sIAAAAAAACA3VSXU/DIBR9368gnfqi66bJXmZLYuKb0Qdj9k7LhRIRGmBus+l/l0u7r0R5oHB7
+7uGcA13nmJFA8rWCre/7SdHSIrBKA6lkbbV15RTiEIJsFQ9Neb9YXEeEI0wracoaTAAXC5wWwppA
+vPqB8vaBdl3+xr6g74s51mkxR8g8OJyQP35bisdF5DP42qk2KGuwYai/AvMbBytSVIgZt/i/ojem
+8u3jMD9J6UAybD5gz0oDfuC89JVNhRBLgIwIF5WWld2RGrT2LauVkeUi7VrGOe4e6ITEgc5PBDGY
+JYeMTrpuRsYgP5h8gT1GScZRhGZMS4MIqA9FhSaldu7kfE55JV4w/E8yp2QT6JrpDSS2QR8mHNsG
+MVfObpXhsLtLS7IqSf5u0z0jsxIEvsGcYFFX9OfDXkOZVaz+lM5uDF8RdJpF5aA9JBCuDSezkenk
+Pem5dM+PlvnwUP61xkdrWfKGB+aJb2y+cDj0Hh7TsfAL+IcyvNECAAA=
+`,
+	},
+
 	"/templates/summary.html": {
 		local:   "templates/summary.html",
 		size:    1619,
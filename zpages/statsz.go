@@ -0,0 +1,182 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package zpages
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"go.opencensus.io/stats/view"
+)
+
+var (
+	viewsMu sync.Mutex // protects views
+	// views holds the views seen by viewsExporter, keyed by name.
+	views = make(map[string]*view.View)
+)
+
+// viewsExporter records which views are registered. Their rows are read with
+// view.RetrieveData when a page is rendered, so that they are up to date.
+type viewsExporter struct{}
+
+func (viewsExporter) ExportView(vd *view.Data) {
+	viewsMu.Lock()
+	views[vd.View.Name] = vd.View
+	viewsMu.Unlock()
+}
+
+func statszHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	WriteHTMLStatszPage(w)
+}
+
+// WriteHTMLStatszPage writes an HTML document to w containing the rows of
+// the registered views.
+//
+// Views are listed once they have been reported at least once after the
+// z-pages were installed; see view.SetReportingPeriod.
+func WriteHTMLStatszPage(w io.Writer) {
+	if err := headerTemplate.Execute(w, headerData{Title: "Stats"}); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+	WriteHTMLStatszSummary(w)
+	if err := footerTemplate.Execute(w, nil); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
+// WriteHTMLStatszSummary writes HTML to w containing the rows of the
+// registered views.
+//
+// It includes neither a header nor footer, so you can embed this data in other pages.
+func WriteHTMLStatszSummary(w io.Writer) {
+	if err := statszTemplate.Execute(w, getStatszPage()); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
+// WriteTextStatszPage writes formatted text to w containing the rows of the
+// registered views.
+func WriteTextStatszPage(w io.Writer) {
+	for i, v := range getStatszPage().Views {
+		if i > 0 {
+			fmt.Fprint(w, "\n")
+		}
+		fmt.Fprintf(w, "%s (%s, %s):\n", v.Name, v.Measure, v.Aggregation)
+		tw := tabwriter.NewWriter(w, 6, 8, 1, ' ', 0)
+		fmt.Fprintf(tw, "%s\n", strings.Join(append(v.TagKeys, "Value"), "\t"))
+		for _, r := range v.Rows {
+			fmt.Fprintf(tw, "%s\n", strings.Join(append(r.TagValues, r.Value), "\t"))
+		}
+		tw.Flush()
+	}
+}
+
+type statszPage struct {
+	Views []statszView
+}
+
+type statszView struct {
+	Name        string
+	Description string
+	Measure     string
+	Aggregation string
+	TagKeys     []string
+	Rows        []statszRow
+}
+
+type statszRow struct {
+	// TagValues holds the value of each of the view's tag keys, in order.
+	TagValues []string
+	Value     string
+}
+
+func getStatszPage() *statszPage {
+	viewsMu.Lock()
+	vs := make([]*view.View, 0, len(views))
+	for name, v := range views {
+		// Drop views that have been unregistered since they were exported.
+		if view.Find(name) != v {
+			delete(views, name)
+			continue
+		}
+		vs = append(vs, v)
+	}
+	viewsMu.Unlock()
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Name < vs[j].Name })
+
+	page := &statszPage{}
+	for _, v := range vs {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			continue
+		}
+		sv := statszView{
+			Name:        v.Name,
+			Description: v.Description,
+			Measure:     v.Measure.Name(),
+			Aggregation: v.Aggregation.Type.String(),
+		}
+		for _, k := range v.TagKeys {
+			sv.TagKeys = append(sv.TagKeys, k.Name())
+		}
+		for _, r := range rows {
+			sr := statszRow{
+				TagValues: make([]string, len(v.TagKeys)),
+				Value:     aggregationValue(r.Data),
+			}
+			for _, t := range r.Tags {
+				for i, k := range v.TagKeys {
+					if t.Key == k {
+						sr.TagValues[i] = t.Value
+					}
+				}
+			}
+			sv.Rows = append(sv.Rows, sr)
+		}
+		sort.Slice(sv.Rows, func(i, j int) bool {
+			return strings.Join(sv.Rows[i].TagValues, "\x00") < strings.Join(sv.Rows[j].TagValues, "\x00")
+		})
+		page.Views = append(page.Views, sv)
+	}
+	return page
+}
+
+// aggregationValue formats the value of an aggregation for display.
+func aggregationValue(data view.AggregationData) string {
+	switch d := data.(type) {
+	case *view.CountData:
+		return fmt.Sprint(d.Value)
+	case *view.SumData:
+		return fmt.Sprint(d.Value)
+	case *view.LastValueData:
+		return fmt.Sprint(d.Value)
+	case *view.DistributionData:
+		return fmt.Sprintf("count=%d mean=%g min=%g max=%g", d.Count, d.Mean, d.Min, d.Max)
+	case *view.MinMaxData:
+		return fmt.Sprintf("min=%g max=%g", d.Min, d.Max)
+	case *view.SummaryData:
+		return fmt.Sprintf("count=%d sum=%g quantiles=%v", d.Count, d.Sum, d.Quantiles)
+	default:
+		return fmt.Sprint(data)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2026, OpenCensus Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package zpages
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestStatszHandler(t *testing.T) {
+	key := tag.MustNewKey("statsz_key")
+	m := stats.Int64("zpages/statsz_test", "a test measure", stats.UnitDimensionless)
+	v := &view.View{
+		Name:        "zpages/statsz_test_count",
+		Description: "count of statsz test measurements",
+		Measure:     m,
+		TagKeys:     []tag.Key{key},
+		Aggregation: view.Count(),
+	}
+	if err := view.Register(v); err != nil {
+		t.Fatalf("view.Register() = %v", err)
+	}
+	defer view.Unregister(v)
+
+	ctx, _ := tag.New(context.Background(), tag.Upsert(key, "statsz_value"))
+	stats.Record(ctx, m.M(1), m.M(1))
+
+	view.SetReportingPeriod(10 * time.Millisecond)
+	defer view.SetReportingPeriod(view.DefaultReportingPeriod)
+
+	mux := http.NewServeMux()
+	Handle(mux, "/debug")
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	var body string
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		res, err := http.Get(server.URL + "/debug/statsz")
+		if err != nil {
+			t.Fatalf("http.Get() = %v", err)
+		}
+		b, err := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatalf("reading response: %v", err)
+		}
+		body = string(b)
+		if strings.Contains(body, v.Name) {
+			break
+		}
+	}
+	for _, want := range []string{v.Name, v.Description, "statsz_key", "statsz_value"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("statsz page does not contain %q:\n%s", want, body)
+		}
+	}
+
+	var text strings.Builder
+	WriteTextStatszPage(&text)
+	if !strings.Contains(text.String(), "statsz_value 2") {
+		t.Errorf("statsz text page = %q; want a row with value 2", text.String())
+	}
+}
@@ -40,6 +40,7 @@ var (
 	headerTemplate       = parseTemplate("header")
 	summaryTableTemplate = parseTemplate("summary")
 	statsTemplate        = parseTemplate("rpcz")
+	statszTemplate       = parseTemplate("statsz")
 	tracesTableTemplate  = parseTemplate("traces")
 	footerTemplate       = parseTemplate("footer")
 )
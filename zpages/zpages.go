@@ -13,9 +13,9 @@
 // limitations under the License.
 //
 
-// Package zpages implements a collection of HTML pages that display RPC stats
-// and trace data, and also functions to write that same data in plain text to
-// an io.Writer.
+// Package zpages implements a collection of HTML pages that display RPC stats,
+// registered view data and trace data, and also functions to write that same
+// data in plain text to an io.Writer.
 //
 // Users can also embed the HTML for stats and traces in custom status pages.
 //
@@ -36,6 +36,7 @@ import (
 	"sync"
 
 	"go.opencensus.io/internal"
+	"go.opencensus.io/stats/view"
 )
 
 // TODO(ramonza): Remove Handler to make initialization lazy.
@@ -56,6 +57,7 @@ func Handle(mux *http.ServeMux, pathPrefix string) {
 		mux = http.DefaultServeMux
 	}
 	mux.HandleFunc(path.Join(pathPrefix, "rpcz"), rpczHandler)
+	mux.HandleFunc(path.Join(pathPrefix, "statsz"), statszHandler)
 	mux.HandleFunc(path.Join(pathPrefix, "tracez"), tracezHandler)
 	mux.Handle(path.Join(pathPrefix, "public/"), http.FileServer(fs))
 }
@@ -66,5 +68,6 @@ func enable() {
 	enableOnce.Do(func() {
 		internal.LocalSpanStoreEnabled = true
 		registerRPCViews()
+		view.RegisterExporter(viewsExporter{})
 	})
 }